@@ -0,0 +1,46 @@
+package beacon
+
+import "sort"
+
+// BeaconNetworks maps the height at which a beacon became active to the
+// BeaconAPI serving rounds from that height on, so the chain can migrate
+// to a new drand network (new chain hash, new group key, new period)
+// without orphaning blocks mined against the old one.
+type BeaconNetworks struct {
+	startHeights []int
+	beacons      map[int]BeaconAPI
+}
+
+// NewBeaconNetworks returns an empty BeaconNetworks; add beacons with
+// Register. The genesis network should be registered at height 0.
+func NewBeaconNetworks() *BeaconNetworks {
+	return &BeaconNetworks{
+		beacons: make(map[int]BeaconAPI),
+	}
+}
+
+// Register adds the beacon that serves rounds for blocks from
+// startHeight onward.
+func (bn *BeaconNetworks) Register(startHeight int, b BeaconAPI) {
+	if _, exists := bn.beacons[startHeight]; !exists {
+		bn.startHeights = append(bn.startHeights, startHeight)
+		sort.Ints(bn.startHeights)
+	}
+	bn.beacons[startHeight] = b
+}
+
+// BeaconForHeight returns the beacon active at blockHeight: the one
+// registered at the largest startHeight not exceeding blockHeight.
+func (bn *BeaconNetworks) BeaconForHeight(blockHeight int) (BeaconAPI, bool) {
+	active := -1
+	for _, start := range bn.startHeights {
+		if start > blockHeight {
+			break
+		}
+		active = start
+	}
+	if active == -1 {
+		return nil, false
+	}
+	return bn.beacons[active], true
+}
@@ -0,0 +1,43 @@
+// Package blockchain holds the block type shared by mining, validation
+// and propagation, and the pools that track blocks as they move through
+// that pipeline.
+package blockchain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/beacon"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/mempool"
+)
+
+// Block is a mined candidate block. It starts out "known" (mined or
+// received over the network) and becomes "accepted" once consensus
+// commits it: AI validation at PREPREPARE, then 2f+1 COMMIT votes.
+type Block struct {
+	Height    int           `json:"height"`
+	Hash      string        `json:"hash"`
+	Timestamp int64         `json:"timestamp"`
+	Txs       []*mempool.Tx `json:"txs"`
+	TxCount   int           `json:"tx_count"`
+	Miner     string        `json:"miner"`
+	Size      int           `json:"size"`
+	// BeaconEntry is the drand round stamped into the block by its
+	// proposer, used as the VRF seed for leader election and passed to
+	// the AI validator so it can check the proposer was legitimately
+	// elected.
+	BeaconEntry *beacon.Entry `json:"beacon_entry,omitempty"`
+}
+
+// ComputeHash derives the block hash from its height, timestamp, miner
+// and the hashes of the transactions it carries, so blocks from two
+// different miners never share a hash. BeaconEntry is not hashed.
+func (b *Block) ComputeHash() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d|%d|%s", b.Height, b.Timestamp, b.Miner)
+	for _, tx := range b.Txs {
+		h.Write([]byte(tx.Hash))
+	}
+	return "0x" + hex.EncodeToString(h.Sum(nil))
+}
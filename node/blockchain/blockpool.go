@@ -0,0 +1,120 @@
+package blockchain
+
+import (
+	"sync"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/mempool"
+)
+
+// BlockPool tracks blocks as they move through the mining/validation
+// pipeline: a block starts out in knownBlocks (mined locally or received
+// over the network) and is promoted to acceptedBlocks once consensus
+// commits it (AI validation at PREPREPARE, then 2f+1 COMMIT votes).
+type BlockPool struct {
+	mu             sync.RWMutex
+	knownBlocks    map[string]*Block
+	acceptedBlocks map[string]*Block
+}
+
+// NewBlockPool returns an empty BlockPool.
+func NewBlockPool() *BlockPool {
+	return &BlockPool{
+		knownBlocks:    make(map[string]*Block),
+		acceptedBlocks: make(map[string]*Block),
+	}
+}
+
+// AddKnownBlock registers a mined or received block as known, making it
+// eligible for validation.
+func (bp *BlockPool) AddKnownBlock(b *Block) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	bp.knownBlocks[b.Hash] = b
+}
+
+// GetKnownBlock looks up a block that has not necessarily been accepted
+// yet.
+func (bp *BlockPool) GetKnownBlock(hash string) (*Block, bool) {
+	bp.mu.RLock()
+	defer bp.mu.RUnlock()
+
+	b, exists := bp.knownBlocks[hash]
+	return b, exists
+}
+
+// AcceptBlock promotes a known block to accepted, reporting false if the
+// block was never known.
+func (bp *BlockPool) AcceptBlock(hash string) bool {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	b, exists := bp.knownBlocks[hash]
+	if !exists {
+		return false
+	}
+
+	bp.acceptedBlocks[hash] = b
+	return true
+}
+
+// GetBlock looks up a block by hash, checking accepted blocks first
+// since that's the common case for RPC lookups.
+func (bp *BlockPool) GetBlock(hash string) (*Block, bool) {
+	bp.mu.RLock()
+	defer bp.mu.RUnlock()
+
+	if b, exists := bp.acceptedBlocks[hash]; exists {
+		return b, true
+	}
+	b, exists := bp.knownBlocks[hash]
+	return b, exists
+}
+
+// KnownBlocks returns a snapshot of every known block.
+func (bp *BlockPool) KnownBlocks() []*Block {
+	bp.mu.RLock()
+	defer bp.mu.RUnlock()
+
+	blocks := make([]*Block, 0, len(bp.knownBlocks))
+	for _, b := range bp.knownBlocks {
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
+
+// AcceptedBlocks returns a snapshot of every accepted block.
+func (bp *BlockPool) AcceptedBlocks() []*Block {
+	bp.mu.RLock()
+	defer bp.mu.RUnlock()
+
+	blocks := make([]*Block, 0, len(bp.acceptedBlocks))
+	for _, b := range bp.acceptedBlocks {
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
+
+// PruneAcceptedBlocks is called when a new consensus round starts. Any
+// transaction in a block that never made it into a finalized block -
+// whether it was accepted by AI validation or just sat known (e.g. this
+// node isn't running -validator, or the block failed AI) - is returned
+// to the mempool with its Merkle proof cleared, and the round's
+// accepted/known blocks are dropped from the pool.
+func (bp *BlockPool) PruneAcceptedBlocks(mp *mempool.Mempool) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	for _, b := range bp.acceptedBlocks {
+		mp.Return(b.Txs)
+	}
+	for hash, b := range bp.knownBlocks {
+		if _, accepted := bp.acceptedBlocks[hash]; accepted {
+			continue
+		}
+		mp.Return(b.Txs)
+	}
+
+	bp.acceptedBlocks = make(map[string]*Block)
+	bp.knownBlocks = make(map[string]*Block)
+}
@@ -2,15 +2,37 @@ package cli
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/spf13/cobra"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/conformance"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/logging"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/staking"
 )
 
+// stakingNode is the subset of the node the staking commands need. The
+// main.Node type satisfies it.
+type stakingNode interface {
+	ValidatorStatus(address string) (staking.ValidatorStatus, bool)
+	RemoveValidator(address string) error
+}
+
+var logger = logging.New("cli")
+
+var debug bool
+
 var rootCmd = &cobra.Command{
 	Use:   "zytherion",
 	Short: "Zytherion Blockchain Node",
 	Long:  "A hybrid PoW + PoS blockchain with AI validation",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		logging.SetDebug(debug)
+		logger = logging.New("cli")
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug-level console logging")
 }
 
 func Execute(node interface{}) error {
@@ -21,6 +43,7 @@ func Execute(node interface{}) error {
 		stakingCmd(node),
 		contractCmd(),
 		statusCmd(node),
+		conformanceCmd(),
 	)
 
 	return rootCmd.Execute()
@@ -31,7 +54,7 @@ func startCmd(node interface{}) *cobra.Command {
 		Use:   "start",
 		Short: "Start the Zytherion node",
 		Run: func(cmd *cobra.Command, args []string) {
-			log.Println("Starting Zytherion node...")
+			logger.Info("Starting Zytherion node...")
 			// Node startup logic would be here
 		},
 	}
@@ -83,19 +106,42 @@ func stakingCmd(node interface{}) *cobra.Command {
 			},
 		},
 		&cobra.Command{
-			Use:   "unstake",
-			Short: "Unstake ZYTH tokens",
-			Run: func(cmd *cobra.Command, args []string) {
-				fmt.Println("Unstaking tokens...")
-				// Unstaking logic
+			Use:   "unstake [address]",
+			Short: "Begin unbonding a validator's stake",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				n, ok := node.(stakingNode)
+				if !ok {
+					return fmt.Errorf("node does not support staking operations")
+				}
+				if err := n.RemoveValidator(args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Unstaking %s: stake is now unbonding\n", args[0])
+				return nil
 			},
 		},
 		&cobra.Command{
-			Use:   "status",
-			Short: "Check staking status",
-			Run: func(cmd *cobra.Command, args []string) {
-				fmt.Println("Checking staking status...")
-				// Status check logic
+			Use:   "status [address]",
+			Short: "Check a validator's staking status",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				n, ok := node.(stakingNode)
+				if !ok {
+					return fmt.Errorf("node does not support staking operations")
+				}
+				status, exists := n.ValidatorStatus(args[0])
+				if !exists {
+					return fmt.Errorf("validator %s not found", args[0])
+				}
+				fmt.Printf("Address:       %s\n", status.Address)
+				fmt.Printf("Stake:         %d\n", status.StakeAmount)
+				fmt.Printf("Voting power:  %.2f\n", status.VotingPower)
+				fmt.Printf("Active:        %t\n", status.Active)
+				fmt.Printf("Jailed:        %t\n", status.Jailed)
+				fmt.Printf("Unbonding:     %d entries\n", len(status.Unbonding))
+				fmt.Printf("Slash history: %d events\n", len(status.SlashHistory))
+				return nil
 			},
 		},
 	)
@@ -151,3 +197,42 @@ func statusCmd(node interface{}) *cobra.Command {
 		},
 	}
 }
+
+func conformanceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "conformance",
+		Short: "Run conformance test vectors",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "run [file]",
+			Short: "Replay a single conformance vector and show the diff against its expected outcome",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				v, err := conformance.LoadVector(args[0])
+				if err != nil {
+					return err
+				}
+
+				result, err := conformance.Run(v)
+				if err != nil {
+					return err
+				}
+
+				if result.Passed() {
+					fmt.Printf("PASS %s\n", v.Name)
+					return nil
+				}
+
+				fmt.Printf("FAIL %s\n", v.Name)
+				for _, line := range result.Diff {
+					fmt.Printf("  %s\n", line)
+				}
+				return fmt.Errorf("vector %s did not match", v.Name)
+			},
+		},
+	)
+
+	return cmd
+}
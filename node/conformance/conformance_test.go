@@ -0,0 +1,64 @@
+package conformance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// defaultVectorsDir is the corpus checked into the repo. Set
+// ZYTHERION_VECTORS_DIR to point the driver at an external corpus
+// instead, e.g. one pinned in a separate test-vectors repo.
+const defaultVectorsDir = "testdata/vectors"
+
+func TestVectors(t *testing.T) {
+	if os.Getenv("SKIP_CONFORMANCE") == "1" {
+		t.Skip("SKIP_CONFORMANCE=1 set")
+	}
+
+	dir := os.Getenv("ZYTHERION_VECTORS_DIR")
+	if dir == "" {
+		dir = defaultVectorsDir
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("reading vectors dir %s: %v", dir, err)
+	}
+
+	found := false
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		found = true
+
+		path := filepath.Join(dir, entry.Name())
+		t.Run(entry.Name(), func(t *testing.T) {
+			v, err := LoadVector(path)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			result, err := Run(v)
+			if err != nil {
+				t.Fatalf("running vector %s: %v", v.Name, err)
+			}
+			if !result.Passed() {
+				t.Errorf("vector %s mismatched:\n%s", v.Name, joinLines(result.Diff))
+			}
+		})
+	}
+
+	if !found {
+		t.Fatalf("no vectors found in %s", dir)
+	}
+}
+
+func joinLines(lines []string) string {
+	out := ""
+	for _, l := range lines {
+		out += "  " + l + "\n"
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/blockchain"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/consensus"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/staking"
+)
+
+// noopBroadcaster satisfies consensus.Broadcaster without touching the
+// network - a vector only asserts this node's own view of the round.
+type noopBroadcaster struct{}
+
+func (noopBroadcaster) BroadcastConsensus(data []byte) error { return nil }
+
+// noopSigner satisfies consensus.Signer without real cryptography - a
+// vector only exercises PREPREPARE from a single node's own perspective,
+// never a peer's vote that would need verifying.
+type noopSigner struct{}
+
+func (noopSigner) Sign(data []byte) ([]byte, error) { return nil, nil }
+
+func (noopSigner) VerifySignature(validator string, data, signature []byte) (bool, error) {
+	return true, nil
+}
+
+// mockAIClient satisfies consensus.AIValidator by posting to the
+// vector's in-process httptest server, the same shape as main.AIClient.
+type mockAIClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (c *mockAIClient) ValidateBlock(block blockchain.Block) (consensus.ValidationResult, error) {
+	resp, err := c.client.Post(c.baseURL+"/validate/block", "application/json", nil)
+	if err != nil {
+		return consensus.ValidationResult{}, err
+	}
+	defer resp.Body.Close()
+
+	var result consensus.ValidationResult
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	return result, err
+}
+
+// Result is the outcome of running a single vector through the
+// pipeline, alongside what the vector expected.
+type Result struct {
+	Vector         *Vector
+	ActualDecision string
+	ActualScore    float64
+	ActualAccepted bool
+	Diff           []string
+}
+
+// Passed reports whether the actual outcome matched the vector exactly.
+func (r *Result) Passed() bool {
+	return len(r.Diff) == 0
+}
+
+// Run feeds v's block through mockAIClient.ValidateBlock (against v's mock
+// AI response) and then the consensus PREPREPARE check, and diffs the
+// resulting decision, score and post-state against what v expects.
+func Run(v *Vector) (*Result, error) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(consensus.ValidationResult{
+			Score:      v.AIResponse.Score,
+			Decision:   v.AIResponse.Decision,
+			Confidence: v.AIResponse.Confidence,
+			Validator:  v.AIResponse.Validator,
+			Block:      v.Block.Hash,
+			Height:     v.Block.Height,
+		})
+	}))
+	defer server.Close()
+
+	sm := staking.NewManager("")
+	for _, vs := range v.PreState.Validators {
+		if err := sm.AddValidator(vs.Address, vs.StakeAmount); err != nil {
+			return nil, fmt.Errorf("conformance: seeding validator %s: %w", vs.Address, err)
+		}
+		if vs.Jailed {
+			sm.JailValidator(vs.Address, "conformance: pre-jailed by vector")
+		}
+	}
+
+	blockPool := blockchain.NewBlockPool()
+	ai := &mockAIClient{baseURL: server.URL, client: &http.Client{}}
+
+	var observed consensus.ValidationResult
+	engine := consensus.NewEngine(
+		consensus.Config{MinAIScore: v.PreState.MinAIScore},
+		"conformance-node",
+		sm,
+		blockPool,
+		ai,
+		noopBroadcaster{},
+		noopSigner{},
+		func(_ blockchain.Block, result consensus.ValidationResult) { observed = result },
+	)
+
+	block := v.Block
+	block.Hash = block.ComputeHash()
+	blockPool.AddKnownBlock(&block)
+
+	engine.HandlePrePrepare(block)
+
+	accepted := false
+	for _, b := range blockPool.AcceptedBlocks() {
+		if b.Hash == block.Hash {
+			accepted = true
+			break
+		}
+	}
+
+	result := &Result{
+		Vector:         v,
+		ActualDecision: observed.Decision,
+		ActualScore:    observed.Score,
+		ActualAccepted: accepted,
+	}
+
+	if result.ActualDecision != v.ExpectedDecision {
+		result.Diff = append(result.Diff, fmt.Sprintf("decision: expected %q, got %q", v.ExpectedDecision, result.ActualDecision))
+	}
+	if result.ActualScore != v.ExpectedScore {
+		result.Diff = append(result.Diff, fmt.Sprintf("score: expected %v, got %v", v.ExpectedScore, result.ActualScore))
+	}
+	if result.ActualAccepted != v.ExpectedPostState.Accepted {
+		result.Diff = append(result.Diff, fmt.Sprintf("post_state.accepted: expected %v, got %v", v.ExpectedPostState.Accepted, result.ActualAccepted))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,73 @@
+// Package conformance runs the node's block-acceptance pipeline
+// (AI validation at PREPREPARE, then the consensus quorum check)
+// against a corpus of test vectors, the way Filecoin's test-vectors
+// project pins expected behavior for its VM.
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/blockchain"
+)
+
+// ValidatorSpec seeds one validator into the staking manager before a
+// vector's block is run through the pipeline.
+type ValidatorSpec struct {
+	Address     string `json:"address"`
+	StakeAmount uint64 `json:"stake_amount"`
+	Jailed      bool   `json:"jailed"`
+}
+
+// PreState is the staking/consensus state a vector's block is
+// evaluated against.
+type PreState struct {
+	Validators []ValidatorSpec `json:"validators"`
+	MinAIScore float64         `json:"min_ai_score"`
+}
+
+// AIResponse is the canned response the vector's in-process mock AI
+// server returns for the block under test.
+type AIResponse struct {
+	Score      float64 `json:"score"`
+	Decision   string  `json:"decision"`
+	Confidence float64 `json:"confidence"`
+	Validator  string  `json:"validator"`
+}
+
+// PostState is the observable result after the block has cleared (or
+// failed) PREPREPARE.
+type PostState struct {
+	Accepted bool `json:"accepted"`
+}
+
+// Vector is one conformance test case: a pre-state, a candidate block,
+// the AI response the mock server should hand back, and the decision,
+// score and post-state the pipeline is expected to produce.
+type Vector struct {
+	Name              string           `json:"name"`
+	PreState          PreState         `json:"pre_state"`
+	Block             blockchain.Block `json:"block"`
+	AIResponse        AIResponse       `json:"ai_response"`
+	ExpectedDecision  string           `json:"expected_decision"`
+	ExpectedScore     float64          `json:"expected_score"`
+	ExpectedPostState PostState        `json:"expected_post_state"`
+}
+
+// LoadVector reads and decodes a single vector file.
+func LoadVector(path string) (*Vector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("conformance: reading vector %s: %w", path, err)
+	}
+
+	var v Vector
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("conformance: decoding vector %s: %w", path, err)
+	}
+	if v.Name == "" {
+		v.Name = path
+	}
+	return &v, nil
+}
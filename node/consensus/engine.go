@@ -0,0 +1,268 @@
+// Package consensus implements the three-phase (PREPREPARE / PREPARE /
+// COMMIT) round that gates AI validation on actual block acceptance,
+// replacing the old fire-and-forget post-mining AI call.
+package consensus
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/blockchain"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/staking"
+)
+
+// ConsensusProtocol is the libp2p protocol ID for consensus traffic.
+// Nothing opens streams on it: PREPARE/COMMIT messages are gossiped on
+// p2p's zytherion/consensus GossipSub topic, and candidate blocks on
+// the zytherion/blocks topic.
+const ConsensusProtocol = "/zytherion/consensus/1.0.0"
+
+// AIValidator is satisfied by main.AIClient; every validator calls it
+// locally at PREPREPARE rather than trusting a single proposer's say-so.
+type AIValidator interface {
+	ValidateBlock(block blockchain.Block) (ValidationResult, error)
+}
+
+// Broadcaster gossips a consensus message to the rest of the network.
+// p2p.Network implements it over the zytherion/consensus GossipSub topic.
+type Broadcaster interface {
+	BroadcastConsensus(data []byte) error
+}
+
+// Signer signs this node's own PREPARE/COMMIT votes and verifies a
+// peer's, so a validator address in a vote can't be spoofed by whoever
+// relays it. p2p.Network implements it using the host's libp2p keypair.
+type Signer interface {
+	Sign(data []byte) ([]byte, error)
+	VerifySignature(validator string, data, signature []byte) (bool, error)
+}
+
+// Config tunes how strict PREPREPARE acceptance is.
+type Config struct {
+	// MinAIScore is the minimum AI validation score a block needs to
+	// advance from PREPREPARE to PREPARE.
+	MinAIScore float64
+}
+
+// equivocationSlashFraction is how much of a validator's stake is
+// burned the first time it's caught double-signing a PREPARE vote.
+const equivocationSlashFraction = 0.05
+
+// Engine runs the PREPREPARE/PREPARE/COMMIT round for each candidate
+// block and applies slashing when a validator equivocates.
+type Engine struct {
+	config      Config
+	self        string
+	staking     *staking.Manager
+	blockPool   *blockchain.BlockPool
+	ai          AIValidator
+	broadcaster Broadcaster
+	signer      Signer
+	audit       func(blockchain.Block, ValidationResult)
+
+	mu     sync.Mutex
+	rounds map[int]*Round // by height, so conflicting votes for two different block hashes at the same height land in the same Round and can be compared
+}
+
+// NewEngine builds a consensus Engine. self is this node's validator
+// address, used to sign PREPARE/COMMIT votes. audit, if non-nil, is
+// called with the local AI result for every PREPREPARE processed - the
+// audit trail the old post-mining log used to be.
+func NewEngine(config Config, self string, sm *staking.Manager, bp *blockchain.BlockPool, ai AIValidator, broadcaster Broadcaster, signer Signer, audit func(blockchain.Block, ValidationResult)) *Engine {
+	return &Engine{
+		config:      config,
+		self:        self,
+		staking:     sm,
+		blockPool:   bp,
+		ai:          ai,
+		broadcaster: broadcaster,
+		signer:      signer,
+		audit:       audit,
+		rounds:      make(map[int]*Round),
+	}
+}
+
+// voteSigningBytes is the canonical payload a PREPARE/COMMIT vote signs
+// over. kind is mixed in so a signature from one phase can't be replayed
+// as a vote for the other.
+func voteSigningBytes(kind string, height int, blockHash string) []byte {
+	return []byte(fmt.Sprintf("%s:%d:%s", kind, height, blockHash))
+}
+
+// HandlePrePrepare processes a candidate block at PREPREPARE: it runs
+// local AI validation and, if the block passes, starts a Round and
+// gossips this node's PREPARE vote.
+func (e *Engine) HandlePrePrepare(block blockchain.Block) (*Round, error) {
+	result, err := e.ai.ValidateBlock(block)
+	if err != nil {
+		return nil, fmt.Errorf("consensus: AI validation failed for block %s: %w", block.Hash, err)
+	}
+
+	if e.audit != nil {
+		e.audit(block, result)
+	}
+
+	round := e.getOrCreateRound(block.Height, block.Hash)
+
+	if result.Decision != "accept" || result.Score < e.config.MinAIScore {
+		return round, fmt.Errorf("consensus: block %s rejected at PREPREPARE (score %.3f, decision %s)", block.Hash, result.Score, result.Decision)
+	}
+
+	round.SetPhase(PhasePrepare)
+	return round, e.broadcastPrepare(round)
+}
+
+func (e *Engine) broadcastPrepare(round *Round) error {
+	vote := &PrepareMessage{
+		Height:    round.Height,
+		BlockHash: round.BlockHash,
+		Validator: e.self,
+	}
+	sig, err := e.signer.Sign(voteSigningBytes("prepare", vote.Height, vote.BlockHash))
+	if err != nil {
+		return fmt.Errorf("consensus: signing prepare vote: %w", err)
+	}
+	vote.Signature = sig
+	round.AddPrepare(vote)
+
+	if err := e.broadcast("prepare", vote); err != nil {
+		return err
+	}
+	return e.advanceToCommitIfReady(round)
+}
+
+// advanceToCommitIfReady moves the round to PhaseCommit and gossips this
+// node's COMMIT vote once enough PREPARE votes for its block hash have
+// accumulated - whether they arrived from the network or (in a
+// single-validator set) from this node's own vote.
+func (e *Engine) advanceToCommitIfReady(round *Round) error {
+	if round.CurrentPhase() == PhasePrepare && round.PrepareCount() >= e.quorum() {
+		round.SetPhase(PhaseCommit)
+		return e.broadcastCommit(round)
+	}
+	return nil
+}
+
+// HandlePrepare applies a PREPARE vote from the network. If the
+// validator has already voted for a different block hash this round,
+// that's equivocation and the validator is jailed with the conflicting
+// votes as evidence.
+func (e *Engine) HandlePrepare(msg *PrepareMessage) error {
+	if err := e.verifyVote("prepare", msg.Height, msg.BlockHash, msg.Validator, msg.Signature); err != nil {
+		return fmt.Errorf("consensus: rejecting prepare from %s: %w", msg.Validator, err)
+	}
+
+	round := e.getOrCreateRound(msg.Height, msg.BlockHash)
+
+	if conflicting := round.AddPrepare(msg); conflicting != nil {
+		evidence := EquivocationEvidence{
+			Validator: msg.Validator,
+			Height:    msg.Height,
+			First:     *conflicting,
+			Second:    *msg,
+		}
+		if err := e.staking.SlashValidator(msg.Validator, equivocationSlashFraction, fmt.Sprintf(
+			"equivocation: conflicting PREPARE votes for height %d (%s vs %s)",
+			evidence.Height, evidence.First.BlockHash, evidence.Second.BlockHash)); err != nil {
+			return fmt.Errorf("consensus: validator %s equivocated at height %d but slashing failed: %w", msg.Validator, msg.Height, err)
+		}
+		return fmt.Errorf("consensus: validator %s equivocated at height %d", msg.Validator, msg.Height)
+	}
+
+	return e.advanceToCommitIfReady(round)
+}
+
+func (e *Engine) broadcastCommit(round *Round) error {
+	vote := &CommitMessage{
+		Height:    round.Height,
+		BlockHash: round.BlockHash,
+		Validator: e.self,
+	}
+	sig, err := e.signer.Sign(voteSigningBytes("commit", vote.Height, vote.BlockHash))
+	if err != nil {
+		return fmt.Errorf("consensus: signing commit vote: %w", err)
+	}
+	vote.Signature = sig
+	count := round.AddCommit(vote)
+
+	if err := e.broadcast("commit", vote); err != nil {
+		return err
+	}
+
+	if round.CurrentPhase() != PhaseCommitted && count >= e.quorum() {
+		round.SetPhase(PhaseCommitted)
+		e.blockPool.AcceptBlock(round.BlockHash)
+	}
+	return nil
+}
+
+// HandleCommit applies a COMMIT vote from the network. Once 2f+1
+// validators have committed the same block hash, it's inserted into
+// the accepted-blocks cache.
+func (e *Engine) HandleCommit(msg *CommitMessage) error {
+	if err := e.verifyVote("commit", msg.Height, msg.BlockHash, msg.Validator, msg.Signature); err != nil {
+		return fmt.Errorf("consensus: rejecting commit from %s: %w", msg.Validator, err)
+	}
+
+	round := e.getOrCreateRound(msg.Height, msg.BlockHash)
+
+	count := round.AddCommit(msg)
+	if round.CurrentPhase() != PhaseCommitted && count >= e.quorum() {
+		round.SetPhase(PhaseCommitted)
+		e.blockPool.AcceptBlock(round.BlockHash)
+	}
+	return nil
+}
+
+// quorum is 2f+1 for an active validator set tolerating f Byzantine
+// validators, i.e. n >= 3f+1.
+func (e *Engine) quorum() int {
+	n := len(e.staking.GetActiveValidators())
+	if n == 0 {
+		return 1
+	}
+	f := (n - 1) / 3
+	return 2*f + 1
+}
+
+// getOrCreateRound returns the Round tracking height, creating one
+// pinned to blockHash if this is the first candidate seen at that
+// height. Keying by height (rather than by blockHash) is what makes
+// equivocation detectable: a validator's PREPARE votes for two
+// different block hashes at the same height both land in this one
+// Round's vote map, where AddPrepare can compare them.
+func (e *Engine) getOrCreateRound(height int, blockHash string) *Round {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if round, exists := e.rounds[height]; exists {
+		return round
+	}
+	round := NewRound(height, blockHash)
+	e.rounds[height] = round
+	return round
+}
+
+// verifyVote checks that signature over (kind, height, blockHash) was
+// produced by validator, rejecting a vote before it can ever reach
+// AddPrepare/AddCommit - otherwise any peer could gossip a vote under an
+// arbitrary validator address and pollute that validator's tally or
+// frame it for equivocation.
+func (e *Engine) verifyVote(kind string, height int, blockHash, validator string, signature []byte) error {
+	ok, err := e.signer.VerifySignature(validator, voteSigningBytes(kind, height, blockHash), signature)
+	if err != nil {
+		return fmt.Errorf("verifying signature: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+func (e *Engine) broadcast(kind string, payload interface{}) error {
+	data, err := marshalEnvelope(kind, payload)
+	if err != nil {
+		return err
+	}
+	return e.broadcaster.BroadcastConsensus(data)
+}
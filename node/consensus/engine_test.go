@@ -0,0 +1,141 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/blockchain"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/staking"
+)
+
+// stubBroadcaster discards every consensus message - these tests drive
+// HandlePrepare/HandleCommit directly rather than through a real network.
+type stubBroadcaster struct{}
+
+func (stubBroadcaster) BroadcastConsensus(data []byte) error { return nil }
+
+// stubSigner is a Signer whose "signature" is just the signer's own
+// identity, so verifyVote can check a claimed vote's signature without
+// real cryptography: signature == validator means the signature checks
+// out for that validator, regardless of which Engine's signer is asked.
+type stubSigner struct{ self string }
+
+func (s stubSigner) Sign(data []byte) ([]byte, error) { return []byte(s.self), nil }
+
+func (stubSigner) VerifySignature(validator string, data, signature []byte) (bool, error) {
+	return string(signature) == validator, nil
+}
+
+func signedPrepare(height int, blockHash, validator string) *PrepareMessage {
+	return &PrepareMessage{Height: height, BlockHash: blockHash, Validator: validator, Signature: []byte(validator)}
+}
+
+func signedCommit(height int, blockHash, validator string) *CommitMessage {
+	return &CommitMessage{Height: height, BlockHash: blockHash, Validator: validator, Signature: []byte(validator)}
+}
+
+// acceptAI always accepts a block with a score above any reasonable
+// MinAIScore.
+type acceptAI struct{}
+
+func (acceptAI) ValidateBlock(block blockchain.Block) (ValidationResult, error) {
+	return ValidationResult{Decision: "accept", Score: 1}, nil
+}
+
+func newQuorumTestEngine(t *testing.T, self string) (*Engine, *staking.Manager, *blockchain.BlockPool) {
+	t.Helper()
+
+	sm := staking.NewManager("")
+	for _, addr := range []string{"v1", "v2", "v3", "v4"} {
+		if err := sm.AddValidator(addr, 100); err != nil {
+			t.Fatalf("AddValidator(%s): %v", addr, err)
+		}
+	}
+
+	bp := blockchain.NewBlockPool()
+	engine := NewEngine(Config{MinAIScore: 0.5}, self, sm, bp, acceptAI{}, stubBroadcaster{}, stubSigner{self: self}, nil)
+	return engine, sm, bp
+}
+
+func TestEngineReachesQuorumAndAcceptsBlock(t *testing.T) {
+	engine, _, bp := newQuorumTestEngine(t, "v1")
+
+	block := blockchain.Block{Height: 5, Hash: "hashA"}
+	bp.AddKnownBlock(&block)
+	if _, err := engine.HandlePrePrepare(block); err != nil {
+		t.Fatalf("HandlePrePrepare: %v", err)
+	}
+
+	// v1's own PREPARE vote was cast by HandlePrePrepare; two more
+	// distinct validators are needed to reach quorum (2f+1 = 3 of 4).
+	if err := engine.HandlePrepare(signedPrepare(5, "hashA", "v2")); err != nil {
+		t.Fatalf("HandlePrepare(v2): %v", err)
+	}
+	if err := engine.HandlePrepare(signedPrepare(5, "hashA", "v3")); err != nil {
+		t.Fatalf("HandlePrepare(v3): %v", err)
+	}
+
+	// Reaching PREPARE quorum should have auto-advanced to COMMIT and
+	// cast v1's own COMMIT vote; two more COMMITs reach commit quorum.
+	if err := engine.HandleCommit(signedCommit(5, "hashA", "v2")); err != nil {
+		t.Fatalf("HandleCommit(v2): %v", err)
+	}
+	if err := engine.HandleCommit(signedCommit(5, "hashA", "v3")); err != nil {
+		t.Fatalf("HandleCommit(v3): %v", err)
+	}
+
+	// GetBlock also finds merely known blocks, so check the accepted set.
+	accepted := false
+	for _, b := range bp.AcceptedBlocks() {
+		if b.Hash == "hashA" {
+			accepted = true
+		}
+	}
+	if !accepted {
+		t.Errorf("block hashA was not accepted after reaching commit quorum")
+	}
+}
+
+func TestEngineRejectsPrepareWithBadSignature(t *testing.T) {
+	engine, _, _ := newQuorumTestEngine(t, "v1")
+
+	block := blockchain.Block{Height: 5, Hash: "hashA"}
+	if _, err := engine.HandlePrePrepare(block); err != nil {
+		t.Fatalf("HandlePrePrepare: %v", err)
+	}
+
+	forged := &PrepareMessage{Height: 5, BlockHash: "hashA", Validator: "v2", Signature: []byte("not-v2")}
+	if err := engine.HandlePrepare(forged); err == nil {
+		t.Errorf("expected HandlePrepare to reject a vote with an invalid signature")
+	}
+}
+
+func TestEngineDetectsEquivocationAndSlashes(t *testing.T) {
+	engine, sm, _ := newQuorumTestEngine(t, "v1")
+
+	block := blockchain.Block{Height: 5, Hash: "hashA"}
+	if _, err := engine.HandlePrePrepare(block); err != nil {
+		t.Fatalf("HandlePrePrepare: %v", err)
+	}
+
+	if err := engine.HandlePrepare(signedPrepare(5, "hashA", "v2")); err != nil {
+		t.Fatalf("first HandlePrepare(v2): %v", err)
+	}
+
+	// v2 now votes PREPARE for a different block hash at the same
+	// height - this should only be detectable because rounds are keyed
+	// by height, not by block hash.
+	if err := engine.HandlePrepare(signedPrepare(5, "hashB", "v2")); err == nil {
+		t.Errorf("expected HandlePrepare to report v2's conflicting vote as equivocation")
+	}
+
+	status, ok := sm.ValidatorStatus("v2")
+	if !ok {
+		t.Fatalf("validator v2 not found")
+	}
+	if !status.Jailed {
+		t.Errorf("v2 should be jailed after equivocating")
+	}
+	if len(status.SlashHistory) != 1 {
+		t.Errorf("v2 should have exactly one slash event, got %d", len(status.SlashHistory))
+	}
+}
@@ -0,0 +1,47 @@
+package consensus
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// envelope wraps a PrepareMessage or CommitMessage so HandleMessage can
+// tell them apart on the wire.
+type envelope struct {
+	Kind    string          `json:"kind"` // "prepare" or "commit"
+	Payload json.RawMessage `json:"payload"`
+}
+
+func marshalEnvelope(kind string, payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope{Kind: kind, Payload: data})
+}
+
+// HandleMessage decodes a message received on the consensus topic and
+// dispatches it to HandlePrepare or HandleCommit.
+func (e *Engine) HandleMessage(data []byte) error {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return fmt.Errorf("consensus: decoding envelope: %w", err)
+	}
+
+	switch env.Kind {
+	case "prepare":
+		var msg PrepareMessage
+		if err := json.Unmarshal(env.Payload, &msg); err != nil {
+			return fmt.Errorf("consensus: decoding prepare: %w", err)
+		}
+		return e.HandlePrepare(&msg)
+	case "commit":
+		var msg CommitMessage
+		if err := json.Unmarshal(env.Payload, &msg); err != nil {
+			return fmt.Errorf("consensus: decoding commit: %w", err)
+		}
+		return e.HandleCommit(&msg)
+	default:
+		return fmt.Errorf("consensus: unknown message kind %q", env.Kind)
+	}
+}
@@ -0,0 +1,50 @@
+package consensus
+
+// Phase is a stage of the three-phase PBFT-style round.
+type Phase int
+
+const (
+	PhasePrePrepare Phase = iota
+	PhasePrepare
+	PhaseCommit
+	PhaseCommitted
+)
+
+// ValidationResult is a validator's local AI assessment of a candidate
+// block at PREPREPARE. The Engine passes it to its audit callback; it is
+// not gossiped to peers.
+type ValidationResult struct {
+	Score      float64 `json:"score"`
+	Decision   string  `json:"decision"`
+	Confidence float64 `json:"confidence"`
+	Validator  string  `json:"validator"`
+	Block      string  `json:"block"`
+	Height     int     `json:"height"`
+}
+
+// PrepareMessage is gossiped once a validator's local AI check passes
+// MinAIScore for a candidate block.
+type PrepareMessage struct {
+	Height    int    `json:"height"`
+	BlockHash string `json:"block_hash"`
+	Validator string `json:"validator"`
+	Signature []byte `json:"signature"`
+}
+
+// CommitMessage is gossiped once a validator has collected 2f+1 PREPARE
+// votes for a block.
+type CommitMessage struct {
+	Height    int    `json:"height"`
+	BlockHash string `json:"block_hash"`
+	Validator string `json:"validator"`
+	Signature []byte `json:"signature"`
+}
+
+// EquivocationEvidence is recorded when the same validator signs two
+// conflicting PREPARE votes for the same round.
+type EquivocationEvidence struct {
+	Validator string
+	Height    int
+	First     PrepareMessage
+	Second    PrepareMessage
+}
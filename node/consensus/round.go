@@ -0,0 +1,94 @@
+package consensus
+
+import "sync"
+
+// Round tracks PREPARE/COMMIT votes for one candidate block at one
+// height. Only one block hash per height can reach PhaseCommitted,
+// because the validator set only signs PREPARE for the block its own
+// AI check accepted.
+type Round struct {
+	mu sync.Mutex
+
+	Height    int
+	BlockHash string
+	Phase     Phase
+
+	prepareVotes map[string]*PrepareMessage // by validator address
+	commitVotes  map[string]*CommitMessage  // by validator address
+}
+
+// NewRound starts a round in PhasePrePrepare for the given candidate
+// block.
+func NewRound(height int, blockHash string) *Round {
+	return &Round{
+		Height:       height,
+		BlockHash:    blockHash,
+		Phase:        PhasePrePrepare,
+		prepareVotes: make(map[string]*PrepareMessage),
+		commitVotes:  make(map[string]*CommitMessage),
+	}
+}
+
+// AddPrepare records a PREPARE vote. If the validator already voted for
+// a different block hash this round, the previous vote is returned
+// alongside the new one so the caller can raise equivocation evidence;
+// the newer vote is still recorded.
+func (r *Round) AddPrepare(msg *PrepareMessage) (conflicting *PrepareMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, voted := r.prepareVotes[msg.Validator]; voted && existing.BlockHash != msg.BlockHash {
+		conflicting = existing
+	}
+
+	r.prepareVotes[msg.Validator] = msg
+	return conflicting
+}
+
+// PrepareCount returns how many distinct validators have voted PREPARE
+// for this round's block hash.
+func (r *Round) PrepareCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, vote := range r.prepareVotes {
+		if vote.BlockHash == r.BlockHash {
+			count++
+		}
+	}
+	return count
+}
+
+// AddCommit records a COMMIT vote and returns the number of distinct
+// validators that have committed this round's block hash so far.
+func (r *Round) AddCommit(msg *CommitMessage) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.commitVotes[msg.Validator] = msg
+
+	count := 0
+	for _, vote := range r.commitVotes {
+		if vote.BlockHash == r.BlockHash {
+			count++
+		}
+	}
+	return count
+}
+
+// SetPhase advances the round's phase.
+func (r *Round) SetPhase(phase Phase) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Phase = phase
+}
+
+// CurrentPhase reports the round's phase.
+func (r *Round) CurrentPhase() Phase {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.Phase
+}
@@ -0,0 +1,67 @@
+// Package logging wraps zap so every package gets structured, leveled
+// logging instead of emoji-prefixed log.Printf calls, with a single
+// place to switch between a human-readable console encoder for local
+// development and a JSON encoder for production log aggregation.
+package logging
+
+import (
+	"os"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+var debugMode atomic.Bool
+
+// SetDebug selects the development console encoder over the production
+// JSON encoder for loggers New creates afterward. Loggers created
+// earlier, such as package-level loggers built at init, keep the
+// encoder and level they were built with.
+func SetDebug(debug bool) {
+	debugMode.Store(debug)
+}
+
+// New returns a logger scoped to component, e.g. logging.New("p2p").
+// Every log line carries a "component" field so lines from different
+// subsystems can be filtered apart once shipped to an aggregator.
+func New(component string) *zap.Logger {
+	var core zapcore.Core
+	if debugMode.Load() {
+		core = zapcore.NewCore(
+			zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
+			zapcore.Lock(os.Stdout),
+			zapcore.DebugLevel,
+		)
+	} else {
+		core = zapcore.NewCore(
+			zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+			zapcore.Lock(os.Stdout),
+			zapcore.InfoLevel,
+		)
+	}
+
+	return zap.New(core).With(zap.String("component", component))
+}
+
+// NewFileLogger returns a logger that writes JSON lines to path,
+// rotating it via lumberjack instead of the old append-forever
+// os.OpenFile path BlockLogger used to manage by hand.
+func NewFileLogger(path string) *zap.Logger {
+	writer := zapcore.AddSync(&lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    100, // megabytes
+		MaxBackups: 5,
+		MaxAge:     28, // days
+		Compress:   true,
+	})
+
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		writer,
+		zapcore.InfoLevel,
+	)
+
+	return zap.New(core)
+}
@@ -3,34 +3,126 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"log"
+	"math/big"
 	"net/http"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
 	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/beacon"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/blockchain"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/consensus"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/logging"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/mempool"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/p2p"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/staking"
+)
+
+var logger = logging.New("main")
+
+// minAIScore is the score a candidate block's local AI validation must
+// clear at PREPREPARE to advance to PREPARE.
+const minAIScore = 0.75
+
+// maxBlockTxs caps how many pending transactions a single mined block
+// drains from the mempool.
+const maxBlockTxs = 200
+
+// drandEndpoint and drandChainHash/drandGroupPublicKey identify the
+// public drand chain the testnet currently draws randomness from. These
+// move into config once BeaconNetworks needs to register a second
+// network for a future migration.
+//
+// drandGroupPublicKey is left unset until that migration: DrandBeacon
+// treats an empty key as "skip the pairing check" (see verify in
+// node/beacon/beacon.go), so every entry is currently accepted
+// unverified. Set it before relying on this beacon for anything that
+// needs signature-verified randomness.
+const (
+	drandEndpoint       = "https://api.drand.sh"
+	drandChainHash      = "8990e7a9aaed2ffed73dbd7092123d6f289930540d7651336225dc172e51b2c7"
+	drandGroupPublicKey = ""
 )
 
+func newBeaconNetworks() *beacon.BeaconNetworks {
+	networks := beacon.NewBeaconNetworks()
+	networks.Register(0, beacon.NewDrandBeacon(drandEndpoint, drandChainHash, drandGroupPublicKey))
+	return networks
+}
+
+// beaconEntryForHeight fetches the drand entry a proposer at blockHeight
+// must stamp into its candidate block.
+func (n *Node) beaconEntryForHeight(ctx context.Context, blockHeight int) (*beacon.Entry, error) {
+	b, ok := n.Beacon.BeaconForHeight(blockHeight)
+	if !ok {
+		return nil, fmt.Errorf("no beacon network registered for height %d", blockHeight)
+	}
+	return b.GetEntry(ctx, uint64(blockHeight))
+}
+
+// electProposer picks the validator eligible to propose this round,
+// using the beacon entry as a VRF seed and weighting by voting power:
+// each active validator's score is sha256(randomness||address) divided
+// by its voting power, and the lowest score wins. An empty return means
+// no validator set is active yet (e.g. a bootstrapping single-miner
+// testnet), so any node may propose.
+func electProposer(entry *beacon.Entry, validators []*staking.Validator) string {
+	if len(validators) == 0 {
+		return ""
+	}
+
+	var winner string
+	var bestScore *big.Float
+
+	for _, v := range validators {
+		if v.VotingPower <= 0 {
+			continue
+		}
+
+		h := sha256.Sum256([]byte(entry.Randomness + v.Address))
+		hashInt := new(big.Int).SetBytes(h[:])
+		score := new(big.Float).Quo(new(big.Float).SetInt(hashInt), big.NewFloat(v.VotingPower))
+
+		if bestScore == nil || score.Cmp(bestScore) < 0 {
+			bestScore = score
+			winner = v.Address
+		}
+	}
+
+	return winner
+}
+
 type Node struct {
 	Config       *Config
 	BlockCounter int
 	Mutex        sync.RWMutex
 	AIClient     *AIClient
 	BlockLogger  *BlockLogger
+	Mempool      *mempool.Mempool
+	BlockPool    *blockchain.BlockPool
+	Beacon       *beacon.BeaconNetworks
+	Staking      *staking.Manager
+	Consensus    *consensus.Engine
+	Network      *p2p.Network
 }
 
 type Config struct {
-	NetworkID      string
-	RPCPort        int
-	P2PPort        int
-	DataDir        string
-	IsMiner        bool
-	IsValidator    bool
-	AIValidatorURL string
+	NetworkID       string
+	RPCPort         int
+	P2PPort         int
+	DataDir         string
+	IsMiner         bool
+	IsValidator     bool
+	AIValidatorURL  string
+	UnbondingPeriod uint64
 }
 
 type AIClient struct {
@@ -39,26 +131,17 @@ type AIClient struct {
 }
 
 type BlockLogger struct {
-	LogFile string
-	Mutex   sync.Mutex
+	blocks      *zap.Logger
+	validations *zap.Logger
 }
 
-type Block struct {
-	Height    int    `json:"height"`
-	Hash      string `json:"hash"`
-	Timestamp int64  `json:"timestamp"`
-	TxCount   int    `json:"tx_count"`
-	Miner     string `json:"miner"`
-	Size      int    `json:"size"`
-}
-
-type AIValidationResult struct {
-	Score      float64 `json:"score"`
-	Decision   string  `json:"decision"`
-	Confidence float64 `json:"confidence"`
-	Validator  string  `json:"validator"`
-	Block      string  `json:"block"`
-	Height     int     `json:"height"`
+// newBlockLogger builds the block/validation audit loggers, each writing
+// newline-delimited JSON to its own rotating file under dataDir.
+func newBlockLogger(dataDir string) *BlockLogger {
+	return &BlockLogger{
+		blocks:      logging.NewFileLogger(dataDir + "/blocks.log"),
+		validations: logging.NewFileLogger(dataDir + "/validations.log"),
+	}
 }
 
 func main() {
@@ -69,27 +152,47 @@ func main() {
 	rpcport := flag.Int("rpcport", 8545, "RPC server port")
 	p2pport := flag.Int("p2pport", 30303, "P2P network port")
 	aivalidator := flag.String("aivalidator", "http://ai-validator:5000", "AI Validator URL")
+	debug := flag.Bool("debug", false, "Enable debug-level console logging")
+	unbondingPeriod := flag.Uint64("unbonding-period", staking.DefaultUnbondingPeriod, "Validator unbonding period, in blocks")
 
 	flag.Parse()
 
+	logging.SetDebug(*debug)
+	logger = logging.New("main")
+
 	// Load configuration
 	config := &Config{
-		NetworkID:      "zytherion-testnet",
-		RPCPort:        *rpcport,
-		P2PPort:        *p2pport,
-		DataDir:        *datadir,
-		IsMiner:        *mine,
-		IsValidator:    *validator,
-		AIValidatorURL: *aivalidator,
+		NetworkID:       "zytherion-testnet",
+		RPCPort:         *rpcport,
+		P2PPort:         *p2pport,
+		DataDir:         *datadir,
+		IsMiner:         *mine,
+		IsValidator:     *validator,
+		AIValidatorURL:  *aivalidator,
+		UnbondingPeriod: *unbondingPeriod,
 	}
 
-	log.Printf("🚀 Starting Zytherion Node...")
-	log.Printf("⛏️  Mining: %t", config.IsMiner)
-	log.Printf("✅ Validation: %t", config.IsValidator)
-	log.Printf("🤖 AI Validator: %s", config.AIValidatorURL)
-	log.Printf("📁 Data Directory: %s", config.DataDir)
-	log.Printf("🔌 RPC Port: %d", config.RPCPort)
-	log.Printf("🌐 P2P Port: %d", config.P2PPort)
+	logger.Info("Starting Zytherion Node",
+		zap.Bool("mining", config.IsMiner),
+		zap.Bool("validation", config.IsValidator),
+		zap.String("ai_validator", config.AIValidatorURL),
+		zap.String("data_dir", config.DataDir),
+		zap.Int("rpc_port", config.RPCPort),
+		zap.Int("p2p_port", config.P2PPort),
+	)
+
+	// Ensure data directory exists
+	os.MkdirAll(config.DataDir, 0755)
+
+	// Start node components
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	network, err := p2p.NewNetwork(ctx, config.P2PPort, config.DataDir)
+	if err != nil {
+		logger.Fatal("Failed to start P2P network", zap.Error(err))
+	}
+	network.Start(ctx)
 
 	// Initialize node
 	node := &Node{
@@ -99,17 +202,24 @@ func main() {
 			BaseURL: config.AIValidatorURL,
 			Client:  &http.Client{Timeout: 10 * time.Second},
 		},
-		BlockLogger: &BlockLogger{
-			LogFile: config.DataDir + "/blocks.log",
-		},
+		BlockLogger: newBlockLogger(config.DataDir),
+		Mempool:     mempool.New(),
+		BlockPool:   blockchain.NewBlockPool(),
+		Beacon:      newBeaconNetworks(),
+		Staking:     staking.NewManager(config.DataDir),
+		Network:     network,
 	}
-
-	// Ensure data directory exists
-	os.MkdirAll(config.DataDir, 0755)
-
-	// Start node components
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	node.Staking.SetUnbondingPeriod(config.UnbondingPeriod)
+	node.Consensus = consensus.NewEngine(
+		consensus.Config{MinAIScore: minAIScore},
+		node.Network.ID(),
+		node.Staking,
+		node.BlockPool,
+		node.AIClient,
+		node.Network,
+		node.Network,
+		node.logBlockValidation,
+	)
 
 	// Start mining if enabled
 	if config.IsMiner {
@@ -127,23 +237,22 @@ func main() {
 	// Start block monitor
 	go node.startBlockMonitor(ctx)
 
-	log.Printf("✅ Node started successfully!")
-	log.Printf("📡 RPC endpoint: http://localhost:%d", config.RPCPort)
-	log.Printf("🔗 P2P listening on port: %d", config.P2PPort)
-	log.Printf("🤖 AI Validator: %s", config.AIValidatorURL)
-	log.Printf("💡 Use Ctrl+C to stop the node")
+	logger.Info("Node started successfully",
+		zap.Int("rpc_port", config.RPCPort),
+		zap.Int("p2p_port", config.P2PPort),
+	)
 
 	// Wait for interrupt signal
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	<-sigCh
 
-	log.Println("Shutting down node...")
+	logger.Info("Shutting down node")
 	cancel()
 }
 
 func (n *Node) startMining(ctx context.Context) {
-	log.Printf("⛏️  Starting mining module...")
+	logger.Info("Starting mining module")
 	ticker := time.NewTicker(6 * time.Second)
 	defer ticker.Stop()
 
@@ -154,8 +263,34 @@ func (n *Node) startMining(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			// A new consensus round is starting: return any transactions
+			// from last round's accepted blocks that never finalized,
+			// and release any unbonding stake whose lock period is up.
+			n.BlockPool.PruneAcceptedBlocks(n.Mempool)
+			n.Staking.AdvanceHeight(uint64(blockHeight))
+
+			entry, err := n.beaconEntryForHeight(ctx, blockHeight)
+			if err != nil {
+				logger.Warn("Beacon entry unavailable, skipping round", zap.Int("height", blockHeight), zap.Error(err))
+				blockHeight++
+				continue
+			}
+
+			if proposer := electProposer(entry, n.Staking.GetActiveValidators()); proposer != "" && proposer != n.Network.ID() {
+				logger.Info("Skipping round - not elected proposer", zap.Int("height", blockHeight), zap.String("proposer", proposer))
+				blockHeight++
+				continue
+			}
+
 			block := n.generateNewBlock(blockHeight)
-			log.Printf("⛏️  MINED BLOCK #%d - Hash: %s...", block.Height, block.Hash[:16])
+			block.BeaconEntry = entry
+			block.Hash = block.ComputeHash()
+			n.BlockPool.AddKnownBlock(&block)
+			logger.Info("Mined block", zap.Int("height", block.Height), zap.String("hash", block.Hash), zap.Int("tx_count", block.TxCount))
+
+			if err := n.Network.PublishBlock(ctx, &block); err != nil {
+				logger.Warn("Failed to gossip mined block", zap.String("hash", block.Hash), zap.Error(err))
+			}
 
 			// Validate with AI
 			if n.Config.IsValidator {
@@ -174,7 +309,7 @@ func (n *Node) startMining(ctx context.Context) {
 }
 
 func (n *Node) startValidation(ctx context.Context) {
-	log.Printf("✅ Starting validation module...")
+	logger.Info("Starting validation module")
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
 
@@ -183,17 +318,35 @@ func (n *Node) startValidation(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			log.Printf("✅ Validating network blocks...")
+			logger.Debug("Validating network blocks")
 			// Simulate validating received blocks
 			if n.BlockCounter > 0 {
-				log.Printf("✅ Validated %d blocks in network", n.BlockCounter)
+				logger.Info("Validated blocks in network", zap.Int("count", n.BlockCounter))
 			}
 		}
 	}
 }
 
+// startBlockMonitor logs periodic block stats and is also the node's
+// only consumer of the three GossipSub topics: received blocks feed the
+// block pool (and local AI validation), received txs feed the mempool,
+// and received consensus messages drive the PREPARE/COMMIT engine.
 func (n *Node) startBlockMonitor(ctx context.Context) {
-	log.Printf("📊 Starting block monitor...")
+	logger.Info("Starting block monitor")
+
+	blocksCh, err := p2p.Subscribe[blockchain.Block](ctx, n.Network, p2p.TopicBlocks)
+	if err != nil {
+		logger.Error("Failed to subscribe to blocks topic", zap.Error(err))
+	}
+	txsCh, err := p2p.Subscribe[mempool.Tx](ctx, n.Network, p2p.TopicTxs)
+	if err != nil {
+		logger.Error("Failed to subscribe to txs topic", zap.Error(err))
+	}
+	consensusCh, err := p2p.Subscribe[json.RawMessage](ctx, n.Network, p2p.TopicConsensus)
+	if err != nil {
+		logger.Error("Failed to subscribe to consensus topic", zap.Error(err))
+	}
+
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
 
@@ -205,13 +358,39 @@ func (n *Node) startBlockMonitor(ctx context.Context) {
 			n.Mutex.RLock()
 			blockCount := n.BlockCounter
 			n.Mutex.RUnlock()
-			log.Printf("📊 BLOCK STATS - Total Blocks: %d", blockCount)
+			logger.Info("Block stats", zap.Int("total_blocks", blockCount))
+		case block, ok := <-blocksCh:
+			if !ok {
+				blocksCh = nil
+				continue
+			}
+			n.BlockPool.AddKnownBlock(&block)
+			logger.Info("Received block from network", zap.Int("height", block.Height), zap.String("hash", block.Hash))
+			if n.Config.IsValidator {
+				go n.validateBlockWithAI(block)
+			}
+		case tx, ok := <-txsCh:
+			if !ok {
+				txsCh = nil
+				continue
+			}
+			if err := n.Mempool.Add(&tx); err != nil {
+				logger.Debug("Dropped received transaction", zap.String("hash", tx.Hash), zap.Error(err))
+			}
+		case msg, ok := <-consensusCh:
+			if !ok {
+				consensusCh = nil
+				continue
+			}
+			if err := n.Consensus.HandleMessage(msg); err != nil {
+				logger.Debug("Consensus message not applied", zap.Error(err))
+			}
 		}
 	}
 }
 
 func (n *Node) startRPCServer(ctx context.Context) {
-	log.Printf("🔌 Starting RPC server on port %d...", n.Config.RPCPort)
+	logger.Info("Starting RPC server", zap.Int("port", n.Config.RPCPort))
 
 	// Simple HTTP server for RPC
 	http.HandleFunc("/", n.handleRPC)
@@ -229,84 +408,59 @@ func (n *Node) startRPCServer(ctx context.Context) {
 	server.Shutdown(context.Background())
 }
 
-func (n *Node) generateNewBlock(height int) Block {
-	return Block{
+func (n *Node) generateNewBlock(height int) blockchain.Block {
+	txs := n.Mempool.Drain(maxBlockTxs)
+
+	block := blockchain.Block{
 		Height:    height,
-		Hash:      fmt.Sprintf("0x%x", time.Now().UnixNano()), // Simple hash simulation
 		Timestamp: time.Now().Unix(),
-		TxCount:   height * 2, // Simulate increasing transactions
-		Miner:     "zytherion_miner_01",
-		Size:      256 + (height * 10),
-	}
-}
-
-func (n *Node) validateBlockWithAI(block Block) {
-	validation, err := n.AIClient.ValidateBlock(block)
-	if err != nil {
-		log.Printf("❌ AI Validation failed: %v", err)
-		return
+		Txs:       txs,
+		TxCount:   len(txs),
+		Miner:     n.Network.ID(),
+		Size:      256 + (len(txs) * 10),
 	}
+	block.Hash = block.ComputeHash()
 
-	log.Printf("🤖 AI VALIDATION - Block #%d - Score: %.3f - Decision: %s",
-		block.Height, validation.Score, validation.Decision)
-
-	// Log the validation result
-	n.logBlockValidation(block, validation)
+	return block
 }
 
-func (n *Node) logBlock(block Block, source string) {
-	n.BlockLogger.Mutex.Lock()
-	defer n.BlockLogger.Mutex.Unlock()
-
-	logEntry := map[string]interface{}{
-		"timestamp":    time.Now().Format(time.RFC3339),
-		"block_height": block.Height,
-		"block_hash":   block.Hash,
-		"tx_count":     block.TxCount,
-		"miner":        block.Miner,
-		"source":       source,
-		"size":         block.Size,
-	}
-
-	file, err := os.OpenFile(n.BlockLogger.LogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+// validateBlockWithAI drives the block through PREPREPARE: the AI result
+// (logged as the audit trail by the Engine itself) decides whether it
+// advances to PREPARE, not whether it's already been accepted.
+func (n *Node) validateBlockWithAI(block blockchain.Block) {
+	round, err := n.Consensus.HandlePrePrepare(block)
 	if err != nil {
-		log.Printf("❌ Failed to log block: %v", err)
+		logger.Error("PREPREPARE rejected", zap.Int("height", block.Height), zap.Error(err))
 		return
 	}
-	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	encoder.Encode(logEntry)
+	logger.Info("PREPREPARE accepted", zap.Int("height", block.Height), zap.Int("phase", int(round.CurrentPhase())))
 }
 
-func (n *Node) logBlockValidation(block Block, validation AIValidationResult) {
-	n.BlockLogger.Mutex.Lock()
-	defer n.BlockLogger.Mutex.Unlock()
-
-	logEntry := map[string]interface{}{
-		"timestamp":    time.Now().Format(time.RFC3339),
-		"block_height": block.Height,
-		"block_hash":   block.Hash,
-		"ai_score":     validation.Score,
-		"ai_decision":  validation.Decision,
-		"ai_validator": validation.Validator,
-		"confidence":   validation.Confidence,
-	}
-
-	validationFile := n.Config.DataDir + "/validations.log"
-	file, err := os.OpenFile(validationFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		log.Printf("❌ Failed to log validation: %v", err)
-		return
-	}
-	defer file.Close()
+func (n *Node) logBlock(block blockchain.Block, source string) {
+	n.BlockLogger.blocks.Info("block",
+		zap.Int("block_height", block.Height),
+		zap.String("block_hash", block.Hash),
+		zap.Int("tx_count", block.TxCount),
+		zap.String("miner", block.Miner),
+		zap.String("source", source),
+		zap.Int("size", block.Size),
+	)
+}
 
-	encoder := json.NewEncoder(file)
-	encoder.Encode(logEntry)
+func (n *Node) logBlockValidation(block blockchain.Block, validation consensus.ValidationResult) {
+	n.BlockLogger.validations.Info("validation",
+		zap.Int("block_height", block.Height),
+		zap.String("block_hash", block.Hash),
+		zap.Float64("ai_score", validation.Score),
+		zap.String("ai_decision", validation.Decision),
+		zap.String("ai_validator", validation.Validator),
+		zap.Float64("confidence", validation.Confidence),
+	)
 }
 
 // AI Client methods
-func (ac *AIClient) ValidateBlock(block Block) (AIValidationResult, error) {
+func (ac *AIClient) ValidateBlock(block blockchain.Block) (consensus.ValidationResult, error) {
 	blockData := map[string]interface{}{
 		"height":    block.Height,
 		"hash":      block.Hash,
@@ -314,20 +468,24 @@ func (ac *AIClient) ValidateBlock(block Block) (AIValidationResult, error) {
 		"tx_count":  block.TxCount,
 		"miner":     block.Miner,
 		"size":      block.Size,
+		// The AI validator checks this against the active validator set
+		// and voting power to confirm the proposer was legitimately
+		// elected, not just whoever happened to mine fastest.
+		"beacon_entry": block.BeaconEntry,
 	}
 
 	jsonData, err := json.Marshal(blockData)
 	if err != nil {
-		return AIValidationResult{}, err
+		return consensus.ValidationResult{}, err
 	}
 
 	resp, err := ac.Client.Post(ac.BaseURL+"/validate/block", "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
-		return AIValidationResult{}, err
+		return consensus.ValidationResult{}, err
 	}
 	defer resp.Body.Close()
 
-	var result AIValidationResult
+	var result consensus.ValidationResult
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	return result, err
 }
@@ -353,15 +511,48 @@ func (n *Node) handleBlocks(w http.ResponseWriter, r *http.Request) {
 	defer n.Mutex.RUnlock()
 
 	response := map[string]interface{}{
-		"total_blocks": n.BlockCounter,
-		"network":      n.Config.NetworkID,
-		"timestamp":    time.Now().Unix(),
+		"total_blocks":     n.BlockCounter,
+		"network":          n.Config.NetworkID,
+		"timestamp":        time.Now().Unix(),
+		"known_blocks":     n.BlockPool.KnownBlocks(),
+		"accepted_blocks":  n.BlockPool.AcceptedBlocks(),
+		"pending_tx_count": n.Mempool.Len(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// PendingTransactions returns every transaction currently buffered in
+// the mempool, for the zytherion_getPendingTransactions RPC method.
+func (n *Node) PendingTransactions() []*mempool.Tx {
+	return n.Mempool.Pending()
+}
+
+// BlockByHash looks up a block the node has mined or received, for the
+// zytherion_getBlockByHash RPC method.
+func (n *Node) BlockByHash(hash string) (*blockchain.Block, bool) {
+	return n.BlockPool.GetBlock(hash)
+}
+
+// StakingInfo summarizes the validator set for the
+// zytherion_getStakingInfo RPC method.
+func (n *Node) StakingInfo() staking.StakingInfo {
+	return n.Staking.StakingInfo()
+}
+
+// ValidatorStatus looks up address's full staking status for the
+// zytherion_getValidatorStatus RPC method.
+func (n *Node) ValidatorStatus(address string) (staking.ValidatorStatus, bool) {
+	return n.Staking.ValidatorStatus(address)
+}
+
+// RemoveValidator unstakes address, for the `zytherion staking
+// unstake` CLI command.
+func (n *Node) RemoveValidator(address string) error {
+	return n.Staking.RemoveValidator(address)
+}
+
 func (n *Node) handleStats(w http.ResponseWriter, r *http.Request) {
 	stats := map[string]interface{}{
 		"blocks_mined":       n.BlockCounter,
@@ -0,0 +1,129 @@
+// Package mempool buffers signed transactions that have not yet been
+// included in a known block, and owns the Tx type those transactions
+// share with the blockchain package.
+package mempool
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MerkleProof is attached to a Tx once it has been included in a block so
+// light clients can verify inclusion without the full block body.
+type MerkleProof struct {
+	Leaves []string `json:"leaves"`
+	Root   string   `json:"root"`
+}
+
+// Tx is a signed transaction as it travels through the mempool, a mined
+// block, and (once accepted) back out if the block is pruned.
+type Tx struct {
+	Hash        string       `json:"hash"`
+	From        string       `json:"from"`
+	To          string       `json:"to"`
+	Amount      uint64       `json:"amount"`
+	Nonce       uint64       `json:"nonce"`
+	Signature   []byte       `json:"signature"`
+	Timestamp   int64        `json:"timestamp"`
+	MerkleProof *MerkleProof `json:"merkle_proof,omitempty"`
+}
+
+// Mempool is a FIFO buffer of pending transactions keyed by hash.
+type Mempool struct {
+	mu    sync.Mutex
+	txs   map[string]*Tx
+	order []string
+}
+
+// New returns an empty Mempool.
+func New() *Mempool {
+	return &Mempool{
+		txs: make(map[string]*Tx),
+	}
+}
+
+// Add buffers tx for inclusion in a future block. Duplicate hashes are
+// rejected so the same signed transaction can't be queued twice.
+func (mp *Mempool) Add(tx *Tx) error {
+	if tx == nil || tx.Hash == "" {
+		return fmt.Errorf("mempool: transaction must have a hash")
+	}
+
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	if _, exists := mp.txs[tx.Hash]; exists {
+		return fmt.Errorf("mempool: transaction %s already pending", tx.Hash)
+	}
+
+	mp.txs[tx.Hash] = tx
+	mp.order = append(mp.order, tx.Hash)
+	return nil
+}
+
+// Drain removes and returns up to n of the oldest pending transactions,
+// for a miner assembling a new block.
+func (mp *Mempool) Drain(n int) []*Tx {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	if n > len(mp.order) {
+		n = len(mp.order)
+	}
+
+	drained := make([]*Tx, 0, n)
+	for _, hash := range mp.order[:n] {
+		drained = append(drained, mp.txs[hash])
+		delete(mp.txs, hash)
+	}
+	mp.order = mp.order[n:]
+
+	return drained
+}
+
+// Return puts unfinalized transactions back at the front of the queue,
+// clearing any Merkle-proof fields from their time in a pruned block.
+func (mp *Mempool) Return(txs []*Tx) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	restored := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		if _, exists := mp.txs[tx.Hash]; exists {
+			continue
+		}
+		tx.MerkleProof = nil
+		mp.txs[tx.Hash] = tx
+		restored = append(restored, tx.Hash)
+	}
+	mp.order = append(restored, mp.order...)
+}
+
+// Get looks up a pending transaction by hash.
+func (mp *Mempool) Get(hash string) (*Tx, bool) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	tx, exists := mp.txs[hash]
+	return tx, exists
+}
+
+// Len reports the number of pending transactions.
+func (mp *Mempool) Len() int {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	return len(mp.order)
+}
+
+// Pending returns a snapshot of every pending transaction in FIFO order.
+func (mp *Mempool) Pending() []*Tx {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	pending := make([]*Tx, 0, len(mp.order))
+	for _, hash := range mp.order {
+		pending = append(pending, mp.txs[hash])
+	}
+	return pending
+}
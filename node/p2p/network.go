@@ -2,21 +2,29 @@ package p2p
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"time"
 
 	"github.com/libp2p/go-libp2p"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/host"
-	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
-	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/multiformats/go-multiaddr"
+	"go.uber.org/zap"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/logging"
 )
 
+var logger = logging.New("p2p")
+
 type Network struct {
 	host   host.Host
 	peers  map[peer.ID]*peer.AddrInfo
 	config *Config
+
+	ps     *pubsub.PubSub
+	topics map[string]*pubsub.Topic
+	subs   map[string]*pubsub.Subscription
 }
 
 type Config struct {
@@ -25,11 +33,11 @@ type Config struct {
 	BootstrapPeers []string
 }
 
-func NewNetwork(port int, dataDir string) (*Network, error) {
+func NewNetwork(ctx context.Context, port int, dataDir string) (*Network, error) {
 	// Create libp2p host
 	h, err := libp2p.New(
 		libp2p.ListenAddrStrings(
-			format.Sprintf("/ip4/0.0.0.0/tcp/%d", port),
+			fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", port),
 		),
 	)
 	if err != nil {
@@ -48,14 +56,15 @@ func NewNetwork(port int, dataDir string) (*Network, error) {
 		},
 	}
 
-	// Set stream handlers
-	h.SetStreamHandler(protocol.ID("/zytherion/1.0.0"), net.handleStream)
+	if err := net.joinTopics(ctx); err != nil {
+		return nil, err
+	}
 
 	return net, nil
 }
 
 func (n *Network) Start(ctx context.Context) {
-	log.Printf("P2P node started: %s", n.host.ID())
+	logger.Info("P2P node started", zap.String("peer_id", n.host.ID().String()))
 
 	// Connect to bootstrap peers
 	go n.connectToBootstrapPeers(ctx)
@@ -67,15 +76,6 @@ func (n *Network) Start(ctx context.Context) {
 	go n.maintainPeers(ctx)
 }
 
-func (n *Network) handleStream(s network.Stream) {
-	defer s.Close()
-
-	// Handle incoming streams for block propagation, transaction gossip, etc.
-	log.Printf("New stream from: %s", s.Conn().RemotePeer())
-
-	// Protocol handling would be implemented here
-}
-
 func (n *Network) connectToBootstrapPeers(ctx context.Context) {
 	for _, addrStr := range n.config.BootstrapPeers {
 		addr, err := multiaddr.NewMultiaddr(addrStr)
@@ -89,9 +89,9 @@ func (n *Network) connectToBootstrapPeers(ctx context.Context) {
 		}
 
 		if err := n.host.Connect(ctx, *peerInfo); err != nil {
-			log.Printf("Failed to connect to bootstrap peer: %v", err)
+			logger.Warn("Failed to connect to bootstrap peer", zap.Error(err))
 		} else {
-			log.Printf("Connected to bootstrap peer: %s", peerInfo.ID)
+			logger.Info("Connected to bootstrap peer", zap.String("peer_id", peerInfo.ID.String()))
 		}
 	}
 }
@@ -115,7 +115,7 @@ func (n *Network) startDiscovery(ctx context.Context) {
 func (n *Network) discoverPeers(ctx context.Context) {
 	// Simple discovery - in production, use DHT or MDNS
 	currentPeers := n.host.Network().Peers()
-	log.Printf("Currently connected to %d peers", len(currentPeers))
+	logger.Debug("Peer count", zap.Int("peers", len(currentPeers)))
 }
 
 func (n *Network) maintainPeers(ctx context.Context) {
@@ -137,34 +137,46 @@ func (n *Network) ensureMinPeers(ctx context.Context) {
 	currentPeers := len(n.host.Network().Peers())
 
 	if currentPeers < minPeers {
-		log.Printf("Low peer count (%d), attempting to discover more", currentPeers)
+		logger.Warn("Low peer count, attempting to discover more", zap.Int("peers", currentPeers))
 		n.connectToBootstrapPeers(ctx)
 	}
 }
 
-func (n *Network) BroadcastBlock(blockData []byte) {
-	// Broadcast block to all connected peers
-	for _, peerID := range n.host.Network().Peers() {
-		if n.host.Network().Connectedness(peerID) == network.Connected {
-			go n.sendToPeer(peerID, blockData)
-		}
+func (n *Network) GetPeerCount() int {
+	return len(n.host.Network().Peers())
+}
+
+// ID returns this node's libp2p peer ID, used as its validator address
+// in consensus voting until account-based identities land. Unlike the
+// old hardcoded "zytherion_miner_01" constant, it's unique per node.
+func (n *Network) ID() string {
+	return n.host.ID().String()
+}
+
+// Sign signs data with this node's libp2p host keypair, so a
+// PREPARE/COMMIT vote can be attributed to the validator address that
+// actually cast it. Satisfies consensus.Signer.
+func (n *Network) Sign(data []byte) ([]byte, error) {
+	priv := n.host.Peerstore().PrivKey(n.host.ID())
+	if priv == nil {
+		return nil, fmt.Errorf("p2p: no private key for local host")
 	}
+	return priv.Sign(data)
 }
 
-func (n *Network) sendToPeer(peerID peer.ID, data []byte) {
-	// Send data to specific peer
-	stream, err := n.host.NewStream(context.Background(), peerID, protocol.ID("/zytherion/1.0.0"))
+// VerifySignature checks that signature over data was produced by the
+// key behind validator, a libp2p peer ID string. Ed25519 (the default
+// libp2p key type) embeds the public key directly in the peer ID, so
+// this works even for a validator this host has never connected to.
+// Satisfies consensus.Signer.
+func (n *Network) VerifySignature(validator string, data, signature []byte) (bool, error) {
+	id, err := peer.Decode(validator)
 	if err != nil {
-		return
+		return false, fmt.Errorf("p2p: invalid validator id %q: %w", validator, err)
 	}
-	defer stream.Close()
-
-	_, err = stream.Write(data)
+	pub, err := id.ExtractPublicKey()
 	if err != nil {
-		log.Printf("Failed to send data to peer %s: %v", peerID, err)
+		return false, fmt.Errorf("p2p: extracting public key for %q: %w", validator, err)
 	}
-}
-
-func (n *Network) GetPeerCount() int {
-	return len(n.host.Network().Peers())
+	return pub.Verify(data, signature)
 }
@@ -0,0 +1,205 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/blockchain"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/mempool"
+)
+
+// Topic names for the three GossipSub fan-outs block/tx/consensus
+// propagation runs over, replacing the old one-stream-per-peer pushes.
+const (
+	TopicBlocks    = "zytherion/blocks"
+	TopicTxs       = "zytherion/txs"
+	TopicConsensus = "zytherion/consensus"
+)
+
+var (
+	registerMetricsOnce sync.Once
+
+	messagesTotal *prometheus.CounterVec
+)
+
+// registerMetrics is idempotent so spinning up several Networks in the
+// same process (e.g. in tests) doesn't panic on double registration.
+func registerMetrics() {
+	registerMetricsOnce.Do(func() {
+		messagesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "zytherion_p2p_messages_total",
+			Help: "GossipSub messages by topic and outcome (published, received, rejected).",
+		}, []string{"topic", "outcome"})
+		prometheus.MustRegister(messagesTotal)
+	})
+}
+
+// joinTopics creates the PubSub router and joins all three topics,
+// installing a validator on each that rejects malformed payloads before
+// they're re-gossiped to the rest of the mesh.
+func (n *Network) joinTopics(ctx context.Context) error {
+	registerMetrics()
+
+	ps, err := pubsub.NewGossipSub(ctx, n.host, pubsub.WithMessageIdFn(messageID))
+	if err != nil {
+		return fmt.Errorf("p2p: starting gossipsub: %w", err)
+	}
+	n.ps = ps
+	n.topics = make(map[string]*pubsub.Topic)
+	n.subs = make(map[string]*pubsub.Subscription)
+
+	validators := map[string]pubsub.ValidatorEx{
+		TopicBlocks:    n.validateBlockMessage,
+		TopicTxs:       n.validateTxMessage,
+		TopicConsensus: n.validateConsensusMessage,
+	}
+
+	for name, validator := range validators {
+		if err := ps.RegisterTopicValidator(name, validator); err != nil {
+			return fmt.Errorf("p2p: registering validator for %s: %w", name, err)
+		}
+
+		topic, err := ps.Join(name)
+		if err != nil {
+			return fmt.Errorf("p2p: joining topic %s: %w", name, err)
+		}
+		n.topics[name] = topic
+
+		sub, err := topic.Subscribe()
+		if err != nil {
+			return fmt.Errorf("p2p: subscribing to topic %s: %w", name, err)
+		}
+		n.subs[name] = sub
+	}
+
+	return nil
+}
+
+// messageID dedupes gossip on the hash carried in the payload (block
+// hash / tx hash) rather than libp2p's default peerID+seqno scheme, so a
+// node that both mines a block and receives it back from a peer treats
+// them as the same message.
+func messageID(pmsg *pb.Message) string {
+	var envelope struct {
+		Hash string `json:"hash"`
+	}
+	if err := json.Unmarshal(pmsg.Data, &envelope); err == nil && envelope.Hash != "" {
+		return envelope.Hash
+	}
+	return string(pmsg.Data)
+}
+
+func (n *Network) validateBlockMessage(ctx context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	var block blockchain.Block
+	if err := json.Unmarshal(msg.Data, &block); err != nil || block.Hash == "" {
+		messagesTotal.WithLabelValues(TopicBlocks, "rejected").Inc()
+		return pubsub.ValidationReject
+	}
+	messagesTotal.WithLabelValues(TopicBlocks, "received").Inc()
+	return pubsub.ValidationAccept
+}
+
+func (n *Network) validateTxMessage(ctx context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	var tx mempool.Tx
+	if err := json.Unmarshal(msg.Data, &tx); err != nil || tx.Hash == "" {
+		messagesTotal.WithLabelValues(TopicTxs, "rejected").Inc()
+		return pubsub.ValidationReject
+	}
+	messagesTotal.WithLabelValues(TopicTxs, "received").Inc()
+	return pubsub.ValidationAccept
+}
+
+func (n *Network) validateConsensusMessage(ctx context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	var envelope struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(msg.Data, &envelope); err != nil || envelope.Kind == "" {
+		messagesTotal.WithLabelValues(TopicConsensus, "rejected").Inc()
+		return pubsub.ValidationReject
+	}
+	messagesTotal.WithLabelValues(TopicConsensus, "received").Inc()
+	return pubsub.ValidationAccept
+}
+
+// PublishBlock gossips a mined or relayed block over the blocks topic.
+func (n *Network) PublishBlock(ctx context.Context, block *blockchain.Block) error {
+	data, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+	if err := n.topics[TopicBlocks].Publish(ctx, data); err != nil {
+		return err
+	}
+	messagesTotal.WithLabelValues(TopicBlocks, "published").Inc()
+	return nil
+}
+
+// PublishTx gossips a signed transaction over the txs topic.
+func (n *Network) PublishTx(ctx context.Context, tx *mempool.Tx) error {
+	data, err := json.Marshal(tx)
+	if err != nil {
+		return err
+	}
+	if err := n.topics[TopicTxs].Publish(ctx, data); err != nil {
+		return err
+	}
+	messagesTotal.WithLabelValues(TopicTxs, "published").Inc()
+	return nil
+}
+
+// BroadcastConsensus gossips a PREPARE/COMMIT message over the
+// consensus topic. It satisfies consensus.Broadcaster.
+func (n *Network) BroadcastConsensus(data []byte) error {
+	if err := n.topics[TopicConsensus].Publish(context.Background(), data); err != nil {
+		return err
+	}
+	messagesTotal.WithLabelValues(TopicConsensus, "published").Inc()
+	return nil
+}
+
+// Subscribe returns a typed channel of decoded messages received on
+// topic. T must match the topic's payload type (blockchain.Block for
+// TopicBlocks, mempool.Tx for TopicTxs, or json.RawMessage for
+// TopicConsensus, which consensus.Engine.HandleMessage decodes itself).
+// The channel is closed when ctx is cancelled.
+func Subscribe[T any](ctx context.Context, n *Network, topic string) (<-chan T, error) {
+	sub, ok := n.subs[topic]
+	if !ok {
+		return nil, fmt.Errorf("p2p: not subscribed to topic %s", topic)
+	}
+
+	out := make(chan T, 32)
+	go func() {
+		defer close(out)
+		for {
+			msg, err := sub.Next(ctx)
+			if err != nil {
+				return
+			}
+			// Don't re-deliver messages this host itself published.
+			if msg.ReceivedFrom == n.host.ID() {
+				continue
+			}
+
+			var payload T
+			if err := json.Unmarshal(msg.Data, &payload); err != nil {
+				continue
+			}
+
+			select {
+			case out <- payload:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
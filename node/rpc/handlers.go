@@ -3,16 +3,38 @@ package rpc
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/blockchain"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/logging"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/mempool"
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/staking"
 )
 
+var logger = logging.New("rpc")
+
 type Server struct {
 	port   int
 	node   interface{} // Reference to main node
 	server *http.Server
 }
 
+// blockProvider is the subset of the node the RPC server needs to serve
+// mempool/block-pool queries. The main.Node type satisfies it.
+type blockProvider interface {
+	PendingTransactions() []*mempool.Tx
+	BlockByHash(hash string) (*blockchain.Block, bool)
+}
+
+// stakingProvider is the subset of the node the RPC server needs to
+// serve staking queries. The main.Node type satisfies it.
+type stakingProvider interface {
+	StakingInfo() staking.StakingInfo
+	ValidatorStatus(address string) (staking.ValidatorStatus, bool)
+}
+
 type JSONRPCRequest struct {
 	JSONRPC string        `json:"jsonrpc"`
 	Method  string        `json:"method"`
@@ -48,9 +70,9 @@ func (s *Server) Start() {
 		Handler: mux,
 	}
 
-	log.Printf("RPC server starting on :%d", s.port)
+	logger.Info("RPC server starting", zap.Int("port", s.port))
 	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("RPC server failed: %v", err)
+		logger.Fatal("RPC server failed", zap.Error(err))
 	}
 }
 
@@ -103,31 +125,74 @@ func (s *Server) routeMethod(method string, params []interface{}) (interface{},
 	case "zytherion_getStakingInfo":
 		return s.getStakingInfo()
 	case "zytherion_getValidatorStatus":
-		return s.getValidatorStatus()
+		return s.getValidatorStatus(params)
 	case "zytherion_sendTransaction":
 		return s.sendTransaction(params)
+	case "zytherion_getPendingTransactions":
+		return s.getPendingTransactions()
+	case "zytherion_getBlockByHash":
+		return s.getBlockByHash(params)
 	default:
 		return nil, fmt.Errorf("method not found")
 	}
 }
 
-func (s *Server) getStakingInfo() (map[string]interface{}, error) {
-	// Mock staking info
-	return map[string]interface{}{
-		"totalStaked":    "1000000",
-		"validatorCount": 25,
-		"minimumStake":   "1000",
-		"apy":            "5.2",
-	}, nil
+func (s *Server) getPendingTransactions() ([]*mempool.Tx, error) {
+	provider, ok := s.node.(blockProvider)
+	if !ok {
+		return nil, fmt.Errorf("node does not support pending transaction queries")
+	}
+	return provider.PendingTransactions(), nil
 }
 
-func (s *Server) getValidatorStatus() (map[string]interface{}, error) {
-	return map[string]interface{}{
-		"isValidator":  true,
-		"stakedAmount": "5000",
-		"votingPower":  "70.71",
-		"active":       true,
-	}, nil
+func (s *Server) getBlockByHash(params []interface{}) (*blockchain.Block, error) {
+	if len(params) < 1 {
+		return nil, fmt.Errorf("missing block hash")
+	}
+	hash, ok := params[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("block hash must be a string")
+	}
+
+	provider, ok := s.node.(blockProvider)
+	if !ok {
+		return nil, fmt.Errorf("node does not support block queries")
+	}
+
+	block, exists := provider.BlockByHash(hash)
+	if !exists {
+		return nil, fmt.Errorf("block %s not found", hash)
+	}
+	return block, nil
+}
+
+func (s *Server) getStakingInfo() (staking.StakingInfo, error) {
+	provider, ok := s.node.(stakingProvider)
+	if !ok {
+		return staking.StakingInfo{}, fmt.Errorf("node does not support staking queries")
+	}
+	return provider.StakingInfo(), nil
+}
+
+func (s *Server) getValidatorStatus(params []interface{}) (staking.ValidatorStatus, error) {
+	if len(params) < 1 {
+		return staking.ValidatorStatus{}, fmt.Errorf("missing validator address")
+	}
+	address, ok := params[0].(string)
+	if !ok {
+		return staking.ValidatorStatus{}, fmt.Errorf("validator address must be a string")
+	}
+
+	provider, ok := s.node.(stakingProvider)
+	if !ok {
+		return staking.ValidatorStatus{}, fmt.Errorf("node does not support staking queries")
+	}
+
+	status, exists := provider.ValidatorStatus(address)
+	if !exists {
+		return staking.ValidatorStatus{}, fmt.Errorf("validator %s not found", address)
+	}
+	return status, nil
 }
 
 func (s *Server) sendTransaction(params []interface{}) (string, error) {
@@ -0,0 +1,129 @@
+package staking
+
+import (
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+// persistValidator writes validator's current state to the validators
+// bucket. Callers hold m.mu already; it's a no-op when running without
+// a backing store (dataDir == "").
+func (m *Manager) persistValidator(validator *Validator) {
+	if m.db == nil {
+		return
+	}
+
+	data, err := json.Marshal(validator)
+	if err != nil {
+		logger.Warn("Failed to encode validator for persistence", zap.Error(err))
+		return
+	}
+
+	err = m.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(validatorsBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(validator.Address), data)
+	})
+	if err != nil {
+		logger.Warn("Failed to persist validator", zap.String("address", validator.Address), zap.Error(err))
+	}
+}
+
+// persistUnbonding overwrites the unbonding bucket with m.unbonding in
+// full - the queue is short-lived and small enough that a full rewrite
+// per change is simpler than diffing entries.
+func (m *Manager) persistUnbonding() {
+	if m.db == nil {
+		return
+	}
+
+	data, err := json.Marshal(m.unbonding)
+	if err != nil {
+		logger.Warn("Failed to encode unbonding queue for persistence", zap.Error(err))
+		return
+	}
+
+	err = m.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(unbondingBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte("queue"), data)
+	})
+	if err != nil {
+		logger.Warn("Failed to persist unbonding queue", zap.Error(err))
+	}
+}
+
+// persistSlash appends evidence to address's slash history record.
+func (m *Manager) persistSlash(address string, evidence SlashEvidence) {
+	if m.db == nil {
+		return
+	}
+
+	data, err := json.Marshal(m.slashHistory[address])
+	if err != nil {
+		logger.Warn("Failed to encode slash history for persistence", zap.String("address", address), zap.Error(err))
+		return
+	}
+
+	err = m.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(slashesBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(address), data)
+	})
+	if err != nil {
+		logger.Warn("Failed to persist slash evidence", zap.String("address", address), zap.Error(err))
+	}
+}
+
+// loadFromDisk restores the validator set, unbonding queue and slash
+// history from the existing validators.db, if any of the buckets are
+// already present (e.g. after a restart).
+func (m *Manager) loadFromDisk() error {
+	return m.db.View(func(tx *bolt.Tx) error {
+		if bucket := tx.Bucket(validatorsBucket); bucket != nil {
+			err := bucket.ForEach(func(k, v []byte) error {
+				var validator Validator
+				if err := json.Unmarshal(v, &validator); err != nil {
+					return err
+				}
+				m.validators[string(k)] = &validator
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if bucket := tx.Bucket(unbondingBucket); bucket != nil {
+			if data := bucket.Get([]byte("queue")); data != nil {
+				if err := json.Unmarshal(data, &m.unbonding); err != nil {
+					return err
+				}
+			}
+		}
+
+		if bucket := tx.Bucket(slashesBucket); bucket != nil {
+			err := bucket.ForEach(func(k, v []byte) error {
+				var history []SlashEvidence
+				if err := json.Unmarshal(v, &history); err != nil {
+					return err
+				}
+				m.slashHistory[string(k)] = history
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
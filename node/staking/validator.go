@@ -2,14 +2,42 @@ package staking
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sync"
 	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/zhao-leihan/Zytherion-Blockchain/node/logging"
+)
+
+var logger = logging.New("staking")
+
+// DefaultUnbondingPeriod is how many blocks an unstaked validator's
+// stake stays locked before it's released, giving slashing evidence
+// time to surface before the stake escapes accountability.
+const DefaultUnbondingPeriod = 1000
+
+var (
+	validatorsBucket = []byte("validators")
+	unbondingBucket  = []byte("unbonding")
+	slashesBucket    = []byte("slashes")
 )
 
 type Manager struct {
-	dataDir      string
+	dataDir         string
+	db              *bolt.DB
+	unbondingPeriod uint64
+	currentHeight   uint64
+	isValidating    bool
+
+	mu           sync.Mutex
 	validators   map[string]*Validator
-	isValidating bool
+	unbonding    []UnbondingEntry
+	slashHistory map[string][]SlashEvidence
 }
 
 type Validator struct {
@@ -20,15 +48,83 @@ type Validator struct {
 	Jailed      bool
 }
 
+// UnbondingEntry is stake in transit out of the validator set: it no
+// longer counts toward voting power, but doesn't return to its owner
+// until ReleaseHeight so it remains slashable for misbehavior
+// committed while still bonded.
+type UnbondingEntry struct {
+	Address       string `json:"address"`
+	Amount        uint64 `json:"amount"`
+	ReleaseHeight uint64 `json:"release_height"`
+}
+
+// SlashEvidence records a single slashing event against a validator.
+type SlashEvidence struct {
+	Address      string  `json:"address"`
+	Fraction     float64 `json:"fraction"`
+	BurnedAmount uint64  `json:"burned_amount"`
+	Reason       string  `json:"reason"`
+	Height       uint64  `json:"height"`
+}
+
+// StakingInfo summarizes the whole validator set for the
+// zytherion_getStakingInfo RPC method.
+type StakingInfo struct {
+	TotalStaked    uint64 `json:"total_staked"`
+	ValidatorCount int    `json:"validator_count"`
+	UnbondingCount int    `json:"unbonding_count"`
+}
+
+// ValidatorStatus is one validator's full staking status for the
+// zytherion_getValidatorStatus RPC method and the `zytherion staking
+// status` CLI command.
+type ValidatorStatus struct {
+	Address      string           `json:"address"`
+	StakeAmount  uint64           `json:"stake_amount"`
+	VotingPower  float64          `json:"voting_power"`
+	Active       bool             `json:"active"`
+	Jailed       bool             `json:"jailed"`
+	Unbonding    []UnbondingEntry `json:"unbonding"`
+	SlashHistory []SlashEvidence  `json:"slash_history"`
+}
+
+// NewManager opens (creating if necessary) the validator set persisted
+// under dataDir/validators.db. An empty dataDir runs in-memory only,
+// without touching disk - the conformance harness relies on this to
+// seed a throwaway validator set per vector.
 func NewManager(dataDir string) *Manager {
-	return &Manager{
-		dataDir:    dataDir,
-		validators: make(map[string]*Validator),
+	m := &Manager{
+		dataDir:         dataDir,
+		unbondingPeriod: DefaultUnbondingPeriod,
+		validators:      make(map[string]*Validator),
+		slashHistory:    make(map[string][]SlashEvidence),
+	}
+
+	if dataDir == "" {
+		return m
 	}
+
+	db, err := bolt.Open(filepath.Join(dataDir, "validators.db"), 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		logger.Warn("Failed to open validator store, running without persistence", zap.Error(err))
+		return m
+	}
+	m.db = db
+
+	if err := m.loadFromDisk(); err != nil {
+		logger.Warn("Failed to load validator store", zap.Error(err))
+	}
+	return m
+}
+
+// SetUnbondingPeriod overrides DefaultUnbondingPeriod, e.g. from a CLI
+// flag. Call it before any validator unstakes.
+func (m *Manager) SetUnbondingPeriod(blocks uint64) {
+	m.unbondingPeriod = blocks
 }
 
 func (m *Manager) StartValidation(ctx context.Context) {
-	log.Println("Starting validator manager...")
+	logger.Info("Starting validator manager")
 	m.isValidating = true
 
 	ticker := time.NewTicker(10 * time.Second) // Check every 10 seconds
@@ -56,10 +152,13 @@ func (m *Manager) validateBlocks(ctx context.Context) {
 	// 3. Cast votes
 	// 4. Participate in consensus
 
-	log.Println("Performing block validation...")
+	logger.Info("Performing block validation")
 }
 
 func (m *Manager) AddValidator(address string, stakeAmount uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	validator := &Validator{
 		Address:     address,
 		StakeAmount: stakeAmount,
@@ -69,37 +168,185 @@ func (m *Manager) AddValidator(address string, stakeAmount uint64) error {
 	}
 
 	m.validators[address] = validator
-	log.Printf("Validator added: %s with stake %d", address, stakeAmount)
+	m.persistValidator(validator)
+	logger.Info("Validator added", zap.String("address", address), zap.Uint64("stake", stakeAmount), zap.Float64("voting_power", validator.VotingPower))
 	return nil
 }
 
+// calculateVotingPower derives quadratic voting power from stake:
+// sqrt(stakeAmount), so a validator staking 100x another's stake gets
+// only 10x its voting power rather than 100x, capping whale dominance.
+// The uint64->float64 conversion is exact for every stakeAmount up to
+// 2^53 (float64's mantissa width), far beyond any stake this chain is
+// expected to see, so it introduces no meaningful precision loss.
 func (m *Manager) calculateVotingPower(stakeAmount uint64) float64 {
-	// Quadratic voting to prevent whale dominance
-	return float64(stakeAmount) // Simple linear for now
+	return math.Sqrt(float64(stakeAmount))
 }
 
+// RemoveValidator begins unbonding address's full stake. The validator
+// stops proposing/voting immediately, but its stake isn't released
+// until AdvanceHeight reaches the entry's ReleaseHeight, so it's still
+// slashable for anything it signed while active.
 func (m *Manager) RemoveValidator(address string) error {
-	if validator, exists := m.validators[address]; exists {
-		validator.Active = false
-		log.Printf("Validator removed: %s", address)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	validator, exists := m.validators[address]
+	if !exists {
+		return fmt.Errorf("staking: unknown validator %s", address)
+	}
+	for _, entry := range m.unbonding {
+		if entry.Address == address {
+			return fmt.Errorf("staking: validator %s is already unbonding", address)
+		}
+	}
+
+	validator.Active = false
+	entry := UnbondingEntry{
+		Address:       address,
+		Amount:        validator.StakeAmount,
+		ReleaseHeight: m.currentHeight + m.unbondingPeriod,
 	}
+	m.unbonding = append(m.unbonding, entry)
+
+	m.persistValidator(validator)
+	m.persistUnbonding()
+	logger.Info("Validator unbonding", zap.String("address", address), zap.Uint64("release_height", entry.ReleaseHeight))
 	return nil
 }
 
-func (m *Manager) GetValidatorStatus(address string) (*Validator, bool) {
+// AdvanceHeight tells the manager the chain has reached height,
+// releasing any unbonding entries whose ReleaseHeight has been reached. The
+// mining loop calls this once per round.
+func (m *Manager) AdvanceHeight(height uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.currentHeight = height
+
+	remaining := m.unbonding[:0]
+	for _, entry := range m.unbonding {
+		if height < entry.ReleaseHeight {
+			remaining = append(remaining, entry)
+			continue
+		}
+		delete(m.validators, entry.Address)
+		logger.Info("Unbonding released", zap.String("address", entry.Address), zap.Uint64("amount", entry.Amount))
+	}
+	m.unbonding = remaining
+	m.persistUnbonding()
+}
+
+// ValidatorStatus is address's full staking status - stake, voting
+// power, and unbonding/slash history - for the
+// zytherion_getValidatorStatus RPC method and the `zytherion staking
+// status` CLI command.
+func (m *Manager) ValidatorStatus(address string) (ValidatorStatus, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	validator, exists := m.validators[address]
-	return validator, exists
+	if !exists {
+		return ValidatorStatus{}, false
+	}
+
+	var unbonding []UnbondingEntry
+	for _, entry := range m.unbonding {
+		if entry.Address == address {
+			unbonding = append(unbonding, entry)
+		}
+	}
+
+	return ValidatorStatus{
+		Address:      validator.Address,
+		StakeAmount:  validator.StakeAmount,
+		VotingPower:  validator.VotingPower,
+		Active:       validator.Active,
+		Jailed:       validator.Jailed,
+		Unbonding:    unbonding,
+		SlashHistory: m.slashHistory[address],
+	}, true
+}
+
+// StakingInfo summarizes the validator set for the
+// zytherion_getStakingInfo RPC method.
+func (m *Manager) StakingInfo() StakingInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var total uint64
+	for _, v := range m.validators {
+		total += v.StakeAmount
+	}
+
+	return StakingInfo{
+		TotalStaked:    total,
+		ValidatorCount: len(m.validators),
+		UnbondingCount: len(m.unbonding),
+	}
 }
 
 func (m *Manager) JailValidator(address string, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if validator, exists := m.validators[address]; exists {
 		validator.Jailed = true
 		validator.Active = false
-		log.Printf("Validator jailed: %s - Reason: %s", address, reason)
+		m.persistValidator(validator)
+		logger.Info("Validator jailed", zap.String("address", address), zap.String("reason", reason))
+	}
+}
+
+// SlashValidator burns fraction of address's stake (clamped to [0,1])
+// as a penalty for provable misbehavior - e.g. the equivocation the
+// consensus layer detects on a double-signed PREPARE - and jails the
+// validator so it can't keep proposing while the evidence stands.
+func (m *Manager) SlashValidator(address string, fraction float64, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	validator, exists := m.validators[address]
+	if !exists {
+		return fmt.Errorf("staking: unknown validator %s", address)
 	}
+
+	if fraction < 0 {
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+
+	burned := uint64(float64(validator.StakeAmount) * fraction)
+	validator.StakeAmount -= burned
+	validator.VotingPower = m.calculateVotingPower(validator.StakeAmount)
+	validator.Active = false
+	validator.Jailed = true
+
+	evidence := SlashEvidence{
+		Address:      address,
+		Fraction:     fraction,
+		BurnedAmount: burned,
+		Reason:       reason,
+		Height:       m.currentHeight,
+	}
+	m.slashHistory[address] = append(m.slashHistory[address], evidence)
+
+	m.persistValidator(validator)
+	m.persistSlash(address, evidence)
+	logger.Info("Validator slashed",
+		zap.String("address", address),
+		zap.Float64("fraction", fraction),
+		zap.Uint64("burned", burned),
+		zap.String("reason", reason),
+	)
+	return nil
 }
 
 func (m *Manager) GetActiveValidators() []*Validator {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	var active []*Validator
 	for _, validator := range m.validators {
 		if validator.Active && !validator.Jailed {
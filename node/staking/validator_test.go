@@ -0,0 +1,146 @@
+package staking
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateVotingPowerIsQuadratic(t *testing.T) {
+	m := NewManager("")
+
+	if err := m.AddValidator("validator-a", 100); err != nil {
+		t.Fatalf("AddValidator: %v", err)
+	}
+	if err := m.AddValidator("validator-b", 10000); err != nil {
+		t.Fatalf("AddValidator: %v", err)
+	}
+
+	status, ok := m.ValidatorStatus("validator-a")
+	if !ok {
+		t.Fatalf("validator-a not found")
+	}
+	if got, want := status.VotingPower, math.Sqrt(100); got != want {
+		t.Errorf("validator-a voting power = %v, want %v", got, want)
+	}
+
+	status, ok = m.ValidatorStatus("validator-b")
+	if !ok {
+		t.Fatalf("validator-b not found")
+	}
+	if got, want := status.VotingPower, math.Sqrt(10000); got != want {
+		t.Errorf("validator-b voting power = %v, want %v", got, want)
+	}
+
+	// b staked 100x a's stake but should only get 10x the voting power.
+	if ratio := status.VotingPower / math.Sqrt(100); ratio != 10 {
+		t.Errorf("voting power ratio = %v, want 10", ratio)
+	}
+}
+
+func TestSlashValidatorBurnsStakeAndJails(t *testing.T) {
+	m := NewManager("")
+	if err := m.AddValidator("validator-a", 1000); err != nil {
+		t.Fatalf("AddValidator: %v", err)
+	}
+
+	if err := m.SlashValidator("validator-a", 0.1, "equivocation"); err != nil {
+		t.Fatalf("SlashValidator: %v", err)
+	}
+
+	status, ok := m.ValidatorStatus("validator-a")
+	if !ok {
+		t.Fatalf("validator-a not found")
+	}
+	if status.StakeAmount != 900 {
+		t.Errorf("stake after slash = %d, want 900", status.StakeAmount)
+	}
+	if status.VotingPower != math.Sqrt(900) {
+		t.Errorf("voting power after slash = %v, want %v", status.VotingPower, math.Sqrt(900))
+	}
+	if status.Active {
+		t.Errorf("slashed validator should no longer be active")
+	}
+	if !status.Jailed {
+		t.Errorf("slashed validator should be jailed")
+	}
+	if len(status.SlashHistory) != 1 || status.SlashHistory[0].BurnedAmount != 100 {
+		t.Errorf("slash history = %+v, want one entry burning 100", status.SlashHistory)
+	}
+
+	active := m.GetActiveValidators()
+	for _, v := range active {
+		if v.Address == "validator-a" {
+			t.Errorf("jailed validator-a must not appear in active validator set")
+		}
+	}
+}
+
+func TestSlashValidatorFractionIsClamped(t *testing.T) {
+	m := NewManager("")
+	if err := m.AddValidator("validator-a", 1000); err != nil {
+		t.Fatalf("AddValidator: %v", err)
+	}
+
+	if err := m.SlashValidator("validator-a", 5, "over 100%"); err != nil {
+		t.Fatalf("SlashValidator: %v", err)
+	}
+
+	status, _ := m.ValidatorStatus("validator-a")
+	if status.StakeAmount != 0 {
+		t.Errorf("stake after over-100%% slash = %d, want 0", status.StakeAmount)
+	}
+}
+
+func TestSlashValidatorUnknownAddress(t *testing.T) {
+	m := NewManager("")
+	if err := m.SlashValidator("nobody", 0.1, "reason"); err == nil {
+		t.Errorf("expected error slashing an unknown validator")
+	}
+}
+
+func TestRemoveValidatorReleasesStakeAfterUnbondingPeriod(t *testing.T) {
+	m := NewManager("")
+	m.SetUnbondingPeriod(10)
+	if err := m.AddValidator("validator-a", 1000); err != nil {
+		t.Fatalf("AddValidator: %v", err)
+	}
+
+	if err := m.RemoveValidator("validator-a"); err != nil {
+		t.Fatalf("RemoveValidator: %v", err)
+	}
+
+	status, ok := m.ValidatorStatus("validator-a")
+	if !ok {
+		t.Fatalf("validator-a not found")
+	}
+	if status.Active {
+		t.Errorf("unbonding validator should be inactive immediately")
+	}
+	if len(status.Unbonding) != 1 || status.Unbonding[0].ReleaseHeight != 10 {
+		t.Errorf("unbonding entry = %+v, want release height 10", status.Unbonding)
+	}
+
+	m.AdvanceHeight(5)
+	if _, ok := m.ValidatorStatus("validator-a"); !ok {
+		t.Errorf("validator-a should still be present before its release height")
+	}
+
+	m.AdvanceHeight(10)
+	if _, ok := m.ValidatorStatus("validator-a"); ok {
+		t.Errorf("validator-a should have been released at its release height")
+	}
+}
+
+func TestRemoveValidatorRejectsDoubleUnbonding(t *testing.T) {
+	m := NewManager("")
+	if err := m.AddValidator("validator-a", 1000); err != nil {
+		t.Fatalf("AddValidator: %v", err)
+	}
+	if err := m.RemoveValidator("validator-a"); err != nil {
+		t.Fatalf("first RemoveValidator: %v", err)
+	}
+
+	if err := m.RemoveValidator("validator-a"); err == nil {
+		t.Errorf("expected error unbonding an already-unbonding validator")
+	}
+}